@@ -1,7 +1,155 @@
 package controller
 
-import gogin "github.com/gin-gonic/gin"
+import (
+	"app-server/logic"
+	"app-server/models"
+	"app-server/pkg/errcode"
+	"app-server/pkg/jwt"
+	"app-server/pkg/logger"
+	"errors"
+	"net/http"
+	"strconv"
 
+	gogin "github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// SignUp 注册
+func SignUp(ctx *gogin.Context) {
+	p := new(models.ParamSignUp)
+	if err := BindAndValidate(ctx, p); err != nil {
+		HandleError(ctx, err)
+		return
+	}
+	user, err := logic.SignUp(ctx.Request.Context(), p)
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+	ResponseSuccess(ctx, user)
+}
+
+// Login 登录 成功后返回 access token 与 refresh token
 func Login(ctx *gogin.Context) {
+	p := new(models.ParamLogin)
+	if err := BindAndValidate(ctx, p); err != nil {
+		HandleError(ctx, err)
+		return
+	}
+	user, pair, err := logic.Login(ctx.Request.Context(), p)
+	if err != nil {
+		HandleError(ctx, err)
+		return
+	}
+	setTokenCookie(ctx, pair)
+	ResponseSuccess(ctx, gogin.H{
+		"user_id":       strconv.FormatInt(user.UserID, 10), // id 超出 js 安全整数范围 以字符串返回
+		"username":      user.Username,
+		"access_token":  pair.AccessToken,
+		"refresh_token": pair.RefreshToken,
+		"expires_in":    pair.ExpiresIn,
+
+		"refresh_expires_in": pair.RefreshExpiresIn,
+	})
+}
+
+// Refresh 使用 refresh token 换取新的 token 对 请求体未携带时使用 cookie 中的 refresh token
+func Refresh(ctx *gogin.Context) {
+	p := new(models.ParamRefreshToken)
+	if err := BindAndValidate(ctx, p); err != nil {
+		HandleError(ctx, err)
+		return
+	}
+	token := refreshTokenParam(ctx, p.RefreshToken)
+	if token == "" {
+		HandleError(ctx, errcode.New(CodeInvalidParam).WithLocalizedField("refresh_token",
+			errcode.Bilingual("refresh_token为必填字段", "refresh_token is a required field")))
+		return
+	}
+	pair, err := logic.Refresh(ctx.Request.Context(), token)
+	if err != nil {
+		if _, ok := errcode.From(err); ok {
+			HandleError(ctx, err)
+			return
+		}
+		code := TokenErrorCode(err)
+		if code == CodeServerBusy {
+			logger.WithContext(ctx.Request.Context()).Error("jwt.RefreshToken failed", zap.Error(err))
+			ResponseError(ctx, code)
+			return
+		}
+		logger.WithContext(ctx.Request.Context()).Warn("jwt.RefreshToken failed", zap.Error(err))
+		ResponseErrorWithStatus(ctx, http.StatusUnauthorized, code)
+		return
+	}
+	setTokenCookie(ctx, pair)
+	ResponseSuccess(ctx, pair)
+}
+
+// Logout 退出登录 吊销当前 access token 及请求体或 cookie 中携带的 refresh token
+// refresh token 必须属于当前用户 否则拿到他人 refresh token 的用户可借此让对方下线
+func Logout(ctx *gogin.Context) {
+	p := new(models.ParamLogout)
+	if err := BindAndValidate(ctx, p); err != nil {
+		HandleError(ctx, err)
+		return
+	}
+	v, ok := ctx.Get(CtxtClaimsKey)
+	mc, _ := v.(*jwt.MyClaims)
+	if !ok || mc == nil {
+		ResponseErrorWithStatus(ctx, http.StatusUnauthorized, CodeNeedLogin)
+		return
+	}
+	var refresh *jwt.MyClaims
+	if token := refreshTokenParam(ctx, p.RefreshToken); token != "" {
+		var err error
+		if refresh, err = jwt.ParseRefreshToken(token); err != nil {
+			logger.WithContext(ctx.Request.Context()).Warn("jwt.ParseRefreshToken failed", zap.Error(err))
+		} else if refresh.UserID != mc.UserID {
+			ResponseErrorWithStatus(ctx, http.StatusForbidden, CodeForbidden)
+			return
+		}
+	}
+	if err := jwt.Revoke(ctx.Request.Context(), mc); err != nil {
+		logger.WithContext(ctx.Request.Context()).Error("jwt.Revoke failed", zap.Error(err))
+		ResponseError(ctx, CodeServerBusy)
+		return
+	}
+	if refresh != nil {
+		if err := jwt.RevokeFamily(ctx.Request.Context(), refresh.Family); err != nil {
+			logger.WithContext(ctx.Request.Context()).Warn("jwt.RevokeFamily failed", zap.Error(err))
+		}
+	}
+	clearTokenCookie(ctx)
+	ResponseSuccess(ctx, nil)
+}
+
+// Me 获取当前登录用户
+func Me(ctx *gogin.Context) {
+	u, err := CurrentUser(ctx)
+	if err != nil {
+		ResponseErrorWithStatus(ctx, http.StatusUnauthorized, CodeNeedLogin)
+		return
+	}
+	ResponseSuccess(ctx, u)
+}
 
+// TokenErrorCode 将 token 校验错误转换为业务状态码 非 token 本身的错误（如存储不可用）返回 CodeServerBusy
+func TokenErrorCode(err error) ResCode {
+	switch {
+	case errors.Is(err, jwt.ErrTokenExpired):
+		return CodeTokenExpired
+	case errors.Is(err, jwt.ErrTokenNotValidYet):
+		return CodeTokenNotValidYet
+	case errors.Is(err, jwt.ErrTokenMalformed):
+		return CodeTokenMalformed
+	case errors.Is(err, jwt.ErrSignatureInvalid):
+		return CodeTokenSignatureInvalid
+	case errors.Is(err, jwt.ErrTokenRevoked), errors.Is(err, jwt.ErrTokenReused):
+		return CodeTokenRevoked
+	case errors.Is(err, jwt.ErrInvalidToken), errors.Is(err, jwt.ErrTokenType),
+		errors.Is(err, jwt.ErrInvalidIssuer), errors.Is(err, jwt.ErrInvalidAudience):
+		return CodeInvalidToken
+	}
+	return CodeServerBusy
 }
@@ -0,0 +1,115 @@
+/**
+ * @Author: LiuShuXin
+ * @Description:
+ * @File:  auth_test
+ * Software: Goland
+ * @Date: 2026/10/20 15:00
+ */
+
+package controller
+
+import (
+	"app-server/pkg/jwt"
+	"app-server/settings"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestLogoutForeignRefreshToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	if err := jwt.Init(&settings.Auth{JwtSecret: "test-secret"}, "test"); err != nil {
+		t.Fatal(err)
+	}
+	jwt.SetRefreshStore(jwt.NewMemoryRefreshStore())
+	jwt.SetRevocationStore(jwt.NewMemoryRevocationStore())
+	ctx := context.Background()
+
+	logout := func(access, refresh string) ResponseData {
+		mc, err := jwt.ParseToken(access)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r := gin.New()
+		r.POST("/logout", func(c *gin.Context) {
+			c.Set(CtxtClaimsKey, mc)
+		}, Logout)
+		req := httptest.NewRequest(http.MethodPost, "/logout", strings.NewReader(`{"refresh_token":"`+refresh+`"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		var res ResponseData
+		_ = json.Unmarshal(w.Body.Bytes(), &res)
+		return res
+	}
+	load := func(context.Context, int64) (string, []string, error) { return "jerry", nil, nil }
+
+	tom, _ := jwt.GenTokenPair(ctx, 1, "tom")
+	jerry, _ := jwt.GenTokenPair(ctx, 2, "jerry")
+
+	// 使用他人的 refresh token 退出登录 不能作废对方的轮换链
+	if res := logout(tom.AccessToken, jerry.RefreshToken); res.Code != CodeForbidden {
+		t.Fatalf("foreign refresh token: code = %d", res.Code)
+	}
+	if _, err := jwt.RefreshToken(ctx, jerry.RefreshToken, load); err != nil {
+		t.Fatalf("jerry's family should stay valid, err = %v", err)
+	}
+
+	if res := logout(tom.AccessToken, tom.RefreshToken); res.Code != CodeSuccess {
+		t.Fatalf("own refresh token: code = %d", res.Code)
+	}
+	if _, err := jwt.RefreshToken(ctx, tom.RefreshToken, load); err == nil {
+		t.Error("tom's family should be revoked")
+	}
+}
+
+func TestTokenCookies(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	conf := settings.GetConf()
+	defer func(auth *settings.Auth) { conf.Auth = auth }(conf.Auth)
+	conf.Auth = &settings.Auth{TokenCookie: &settings.TokenCookie{Enable: true, Name: "access_token"}}
+
+	r := gin.New()
+	r.POST("/api/v1/auth/login", func(c *gin.Context) {
+		setTokenCookie(c, &jwt.TokenPair{AccessToken: "a", RefreshToken: "r", ExpiresIn: 900, RefreshExpiresIn: 3600})
+	})
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", nil))
+
+	cookies := map[string]*http.Cookie{}
+	for _, ck := range w.Result().Cookies() {
+		cookies[ck.Name] = ck
+	}
+	if ck := cookies["access_token"]; ck == nil || ck.Value != "a" || ck.Path != "/" || ck.MaxAge != 900 || !ck.HttpOnly {
+		t.Errorf("access cookie = %+v", ck)
+	}
+	// refresh token 只随认证分组下的请求发送
+	if ck := cookies[defaultRefreshCookieName]; ck == nil || ck.Value != "r" || ck.Path != "/api/v1/auth" || ck.MaxAge != 3600 || !ck.HttpOnly {
+		t.Errorf("refresh cookie = %+v", ck)
+	}
+}
+
+func TestRefreshWithoutToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/refresh", Refresh)
+	req := httptest.NewRequest(http.MethodPost, "/refresh", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept-Language", "en")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var res struct {
+		Code ResCode           `json:"code"`
+		Data map[string]string `json:"data"`
+	}
+	_ = json.Unmarshal(w.Body.Bytes(), &res)
+	if res.Code != CodeInvalidParam || res.Data["refresh_token"] == "" {
+		t.Errorf("response = %s", w.Body.String())
+	}
+}
@@ -8,35 +8,32 @@
 
 package controller
 
-type ResCode int64
+import "app-server/pkg/errcode"
+
+// ResCode 业务状态码 定义见 pkg/errcode
+type ResCode = errcode.ResCode
 
 const (
-	CodeSuccess ResCode = 1000 + iota
-	CodeInvalidParam
-	CodeUserExist
-	CodeUserNotExist
-	CodeInvalidPassword
-	CodeServerBusy
+	CodeSuccess         = errcode.CodeSuccess
+	CodeInvalidParam    = errcode.CodeInvalidParam
+	CodeUserExist       = errcode.CodeUserExist
+	CodeUserNotExist    = errcode.CodeUserNotExist
+	CodeInvalidPassword = errcode.CodeInvalidPassword
+	CodeServerBusy      = errcode.CodeServerBusy
 
-	CodeNeedLogin
-	CodeValidToken
+	CodeNeedLogin             = errcode.CodeNeedLogin
+	CodeInvalidToken          = errcode.CodeInvalidToken
+	CodeForbidden             = errcode.CodeForbidden
+	CodeInvalidAPIKey         = errcode.CodeInvalidAPIKey
+	CodeTokenExpired          = errcode.CodeTokenExpired
+	CodeTokenNotValidYet      = errcode.CodeTokenNotValidYet
+	CodeTokenMalformed        = errcode.CodeTokenMalformed
+	CodeTokenSignatureInvalid = errcode.CodeTokenSignatureInvalid
+	CodeTokenRevoked          = errcode.CodeTokenRevoked
+	CodeNotFound              = errcode.CodeNotFound
+	CodeMethodNotAllowed      = errcode.CodeMethodNotAllowed
+	CodeUserDisabled          = errcode.CodeUserDisabled
 )
 
-var codeMsgMap = map[ResCode]string{
-	CodeSuccess:         "success",
-	CodeInvalidParam:    "请求参数错误",
-	CodeUserExist:       "用户名存在",
-	CodeUserNotExist:    "用户名不存在",
-	CodeInvalidPassword: "用户名或密码错误",
-	CodeServerBusy:      "服务繁忙",
-	CodeNeedLogin:       "需要登录",
-	CodeValidToken:      "无效的 token",
-}
-
-func (rc ResCode) Msg() string {
-	msg, ok := codeMsgMap[rc]
-	if !ok {
-		msg = codeMsgMap[CodeServerBusy]
-	}
-	return msg
-}
+// CodeValidToken Deprecated: 请使用 CodeInvalidToken
+const CodeValidToken = CodeInvalidToken
@@ -0,0 +1,98 @@
+/**
+ * @Author: LiuShuXin
+ * @Description: 通过 HttpOnly cookie 下发 token 供浏览器页面使用 access token 随全部请求发送
+ * refresh token 只随认证路由组(刷新、退出登录)下的请求发送
+ * @File:  cookie
+ * Software: Goland
+ * @Date: 2026/10/18 21:00
+ */
+
+package controller
+
+import (
+	"app-server/pkg/jwt"
+	"app-server/settings"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CtxtRefreshTokenKey 保存在上下文中的 cookie 携带的 refresh token 由 middlewares.RefreshTokenCookie 写入
+const CtxtRefreshTokenKey = "refreshToken"
+
+const defaultRefreshCookieName = "refresh_token"
+
+func tokenCookie() *settings.TokenCookie {
+	if settings.GetConf().Auth == nil {
+		return nil
+	}
+	cfg := settings.GetConf().TokenCookie
+	if cfg == nil || !cfg.Enable || cfg.Name == "" {
+		return nil
+	}
+	return cfg
+}
+
+// RefreshCookieName refresh token 的 cookie 名 未开启 cookie 认证时返回空字符串
+func RefreshCookieName() string {
+	cfg := tokenCookie()
+	if cfg == nil {
+		return ""
+	}
+	if cfg.RefreshName != "" {
+		return cfg.RefreshName
+	}
+	return defaultRefreshCookieName
+}
+
+// setTokenCookie 将 access token 及 refresh token 写入 HttpOnly cookie 未开启时不做处理
+func setTokenCookie(c *gin.Context, pair *jwt.TokenPair) {
+	if cfg := tokenCookie(); cfg != nil {
+		writeTokenCookie(c, cfg, cfg.Name, pair.AccessToken, int(pair.ExpiresIn), cfg.Path)
+		writeTokenCookie(c, cfg, RefreshCookieName(), pair.RefreshToken, int(pair.RefreshExpiresIn), refreshCookiePath(c))
+	}
+}
+
+// clearTokenCookie 清除 cookie 中的 token
+func clearTokenCookie(c *gin.Context) {
+	if cfg := tokenCookie(); cfg != nil {
+		writeTokenCookie(c, cfg, cfg.Name, "", -1, cfg.Path)
+		writeTokenCookie(c, cfg, RefreshCookieName(), "", -1, refreshCookiePath(c))
+	}
+}
+
+// refreshCookiePath refresh token cookie 的路径 为当前路由所在的分组 如 /api/v1/auth/login -> /api/v1/auth
+// 登录、刷新与退出登录位于同一分组 因此 refresh token 只会随这几个请求发送
+func refreshCookiePath(c *gin.Context) string {
+	if p := c.FullPath(); p != "" {
+		return path.Dir(p)
+	}
+	return path.Dir(c.Request.URL.Path)
+}
+
+// refreshTokenParam 请求体中的 refresh token 未携带时使用 cookie 中的
+func refreshTokenParam(c *gin.Context, token string) string {
+	if token != "" {
+		return token
+	}
+	return c.GetString(CtxtRefreshTokenKey)
+}
+
+func writeTokenCookie(c *gin.Context, cfg *settings.TokenCookie, name, value string, maxAge int, cookiePath string) {
+	switch strings.ToLower(cfg.SameSite) {
+	case "strict":
+		c.SetSameSite(http.SameSiteStrictMode)
+	case "none":
+		c.SetSameSite(http.SameSiteNoneMode)
+	default:
+		c.SetSameSite(http.SameSiteLaxMode)
+	}
+	if cookiePath == "" {
+		cookiePath = "/"
+	}
+	// 非 debug 模式始终只通过 HTTPS 发送
+	secure := cfg.Secure || settings.GetConf().Mode != gin.DebugMode
+	c.SetCookie(name, value, maxAge, cookiePath, cfg.Domain, secure, true)
+}
@@ -0,0 +1,49 @@
+/**
+ * @Author: LiuShuXin
+ * @Description: 统一错误响应 将 errcode.AppError 转换为 ResponseData
+ * @File:  error
+ * Software: Goland
+ * @Date: 2026/10/18 21:20
+ */
+
+package controller
+
+import (
+	"app-server/pkg/errcode"
+	"app-server/pkg/logger"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// HandleError 将错误转换为统一响应
+// 错误链中包含 AppError 时使用其状态码、提示及字段信息 否则视为内部错误返回 CodeServerBusy
+// 内部错误只记录日志 不把底层错误信息返回给客户端
+func HandleError(c *gin.Context, err error) {
+	if err == nil {
+		return
+	}
+	ae, ok := errcode.From(err)
+	if !ok {
+		ae = errcode.Wrap(err, CodeServerBusy)
+	}
+	lg := logger.WithContext(c.Request.Context())
+	if ae.Code.HTTPStatus() >= http.StatusInternalServerError {
+		lg.Error("request failed",
+			zap.String("path", c.Request.URL.Path),
+			zap.Int64("code", int64(ae.Code)),
+			zap.Error(err))
+	} else {
+		lg.Debug("request rejected",
+			zap.String("path", c.Request.URL.Path),
+			zap.Int64("code", int64(ae.Code)),
+			zap.Error(err))
+	}
+
+	var data interface{}
+	if len(ae.Details) > 0 {
+		data = ae.DetailsFor(Locale(c))
+	}
+	respond(c, errorStatus(ae.Code), ae.Code, ae.MsgFor(Locale(c)), data)
+}
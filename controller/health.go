@@ -0,0 +1,50 @@
+/**
+ * @Author: LiuShuXin
+ * @Description: 存活及就绪探针
+ * @File:  health
+ * Software: Goland
+ * @Date: 2026/10/20 9:50
+ */
+
+package controller
+
+import (
+	"app-server/pkg/health"
+	"app-server/pkg/logger"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// Healthz 存活探针 进程能响应即返回 200
+func Healthz(c *gin.Context) {
+	c.JSON(http.StatusOK, health.Liveness())
+}
+
+// Readyz 就绪探针 依赖检查全部通过时返回 200 否则返回 503 及各项检查状态
+// 失败原因只记录日志 详细结果见管理端口的 ReadyzDetail
+func Readyz(c *gin.Context) {
+	report := health.Readiness(c.Request.Context())
+	status := http.StatusOK
+	if !report.Healthy() {
+		status = http.StatusServiceUnavailable
+		for name, r := range report.Checks {
+			if r.Error != "" {
+				logger.WithContext(c.Request.Context()).Warn("readiness check failed",
+					zap.String("check", name), zap.String("error", r.Error), zap.String("duration", r.Duration))
+			}
+		}
+	}
+	c.JSON(status, report.Public())
+}
+
+// ReadyzDetail 就绪检查的详细结果 包含错误信息及耗时 仅挂载在受保护的管理路由下
+func ReadyzDetail(c *gin.Context) {
+	report := health.Readiness(c.Request.Context())
+	status := http.StatusOK
+	if !report.Healthy() {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, report)
+}
@@ -0,0 +1,33 @@
+/**
+ * @Author: LiuShuXin
+ * @Description: 根据 Accept-Language 协商响应提示的语言
+ * @File:  locale
+ * Software: Goland
+ * @Date: 2026/10/18 22:50
+ */
+
+package controller
+
+import (
+	"app-server/pkg/errcode"
+
+	"github.com/gin-gonic/gin"
+)
+
+const CtxtLocaleKey = "locale"
+
+// Locale 当前请求的语言 如 zh-CN、en-US 同一请求只协商一次
+func Locale(c *gin.Context) string {
+	if v, ok := c.Get(CtxtLocaleKey); ok {
+		if l, ok := v.(string); ok {
+			return l
+		}
+	}
+	var header string
+	if c.Request != nil {
+		header = c.GetHeader("Accept-Language")
+	}
+	l := errcode.Negotiate(header)
+	c.Set(CtxtLocaleKey, l)
+	return l
+}
@@ -8,4 +8,55 @@
 
 package controller
 
-const CtxtUserIDKey = "userID" // 保存在上下文中的UID
+import (
+	"app-server/pkg/errcode"
+	"app-server/pkg/identity"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	CtxtUserIDKey = "userID" // 保存在上下文中的UID
+	CtxtUserKey   = "user"   // 保存在上下文中的当前用户 UserInfo
+	CtxtClaimsKey = "claims" // 保存在上下文中的 JWT 声明 *jwt.MyClaims
+
+	CtxtRequestIDKey = "requestID" // 保存在上下文中的请求 ID
+)
+
+// ErrNeedLogin 当前请求未通过认证 可直接交给 HandleError 返回 CodeNeedLogin
+var ErrNeedLogin = errcode.New(errcode.CodeNeedLogin)
+
+// UserInfo 当前登录用户
+type UserInfo = identity.User
+
+// SetCurrentUser 保存当前用户 同时写入 c.Request 的 context.Context 供 dao、日志等使用
+// 由认证中间件调用
+func SetCurrentUser(c *gin.Context, u UserInfo) {
+	c.Set(CtxtUserIDKey, u.ID)
+	c.Set(CtxtUserKey, u)
+	c.Request = c.Request.WithContext(identity.NewContext(c.Request.Context(), u))
+}
+
+// CurrentUser 获取当前用户 未登录时返回 ErrNeedLogin
+func CurrentUser(c *gin.Context) (UserInfo, error) {
+	v, ok := c.Get(CtxtUserKey)
+	if !ok {
+		return UserInfo{}, ErrNeedLogin
+	}
+	u, ok := v.(UserInfo)
+	if !ok {
+		return UserInfo{}, ErrNeedLogin
+	}
+	return u, nil
+}
+
+// CurrentUserID 获取当前用户 ID 未登录时返回 ErrNeedLogin
+func CurrentUserID(c *gin.Context) (int64, error) {
+	u, err := CurrentUser(c)
+	return u.ID, err
+}
+
+// RequestID 当前请求的请求 ID 由 RequestID 中间件生成
+func RequestID(c *gin.Context) string {
+	return c.GetString(CtxtRequestIDKey)
+}
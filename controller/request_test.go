@@ -0,0 +1,77 @@
+/**
+ * @Author: LiuShuXin
+ * @Description:
+ * @File:  request_test
+ * Software: Goland
+ * @Date: 2026/10/18 18:50
+ */
+
+package controller
+
+import (
+	"app-server/pkg/identity"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestCurrentUser(t *testing.T) {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("GET", "/", nil)
+
+	if _, err := CurrentUser(c); !errors.Is(err, ErrNeedLogin) {
+		t.Fatalf("CurrentUser() err = %v, want %v", err, ErrNeedLogin)
+	}
+
+	SetCurrentUser(c, UserInfo{ID: 1, Username: "tom", Roles: []string{"user"}})
+	u, err := CurrentUser(c)
+	if err != nil || u.ID != 1 || u.Username != "tom" || len(u.Roles) != 1 {
+		t.Errorf("CurrentUser() = %+v, %v", u, err)
+	}
+	if id, _ := CurrentUserID(c); id != 1 {
+		t.Errorf("CurrentUserID() = %d, want 1", id)
+	}
+	if u, ok := identity.FromContext(c.Request.Context()); !ok || u.ID != 1 {
+		t.Errorf("identity.FromContext() = %+v, %v", u, ok)
+	}
+}
+
+func TestHandleErrorNeedLogin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	defer SetStrictHTTPStatus(false)
+
+	for _, strict := range []bool{false, true} {
+		SetStrictHTTPStatus(strict)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("GET", "/", nil)
+		_, err := CurrentUser(c)
+		HandleError(c, err)
+
+		var res ResponseData
+		_ = json.Unmarshal(w.Body.Bytes(), &res)
+		if res.Code != CodeNeedLogin {
+			t.Errorf("strict=%v: code = %d, want %d", strict, res.Code, CodeNeedLogin)
+		}
+		want := http.StatusOK
+		if strict {
+			want = http.StatusUnauthorized
+		}
+		if w.Code != want {
+			t.Errorf("strict=%v: status = %d, want %d", strict, w.Code, want)
+		}
+	}
+
+	// 未经认证中间件时 Me 与中间件一样返回 401
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/me", nil)
+	Me(c)
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Me status = %d, want 401", w.Code)
+	}
+}
@@ -14,31 +14,60 @@ import (
 )
 
 type ResponseData struct {
-	Code ResCode     `json:"code"`
-	Msg  interface{} `json:"msg"`
-	Data interface{} `json:"data,omitempty"`
+	Code      ResCode     `json:"code"`
+	Msg       interface{} `json:"msg"`
+	Data      interface{} `json:"data,omitempty"`
+	RequestID string      `json:"request_id,omitempty"`
+}
+
+// strictHTTPStatus 开启后错误响应使用错误码对应的 HTTP 状态码 否则统一返回 200
+var strictHTTPStatus bool
+
+// SetStrictHTTPStatus 设置错误响应是否使用错误码对应的 HTTP 状态码
+func SetStrictHTTPStatus(enable bool) {
+	strictHTTPStatus = enable
+}
+
+func errorStatus(code ResCode) int {
+	if strictHTTPStatus {
+		return code.HTTPStatus()
+	}
+	return http.StatusOK
 }
 
 func ResponseError(c *gin.Context, code ResCode) {
-	c.JSON(http.StatusOK, &ResponseData{
-		Code: code,
-		Msg:  code.Msg(),
-		Data: nil,
-	})
+	respond(c, errorStatus(code), code, code.MsgFor(Locale(c)), nil)
+}
+
+// ResponseErrorWithStatus 以指定的 HTTP 状态码返回错误 如认证失败返回 401
+func ResponseErrorWithStatus(c *gin.Context, status int, code ResCode) {
+	respond(c, status, code, code.MsgFor(Locale(c)), nil)
 }
 
 func ResponseErrorWithMsg(c *gin.Context, code ResCode, msg interface{}) {
-	c.JSON(http.StatusOK, &ResponseData{
-		Code: code,
-		Msg:  msg,
-		Data: nil,
-	})
+	respond(c, errorStatus(code), code, msg, nil)
 }
 
 func ResponseSuccess(c *gin.Context, data interface{}) {
-	c.JSON(http.StatusOK, &ResponseData{
-		Code: CodeSuccess,
-		Msg:  CodeSuccess.Msg(),
-		Data: data,
+	respond(c, http.StatusOK, CodeSuccess, CodeSuccess.MsgFor(Locale(c)), data)
+}
+
+// respond 输出统一格式的响应 附带请求 ID 便于客户端反馈问题时定位日志
+func respond(c *gin.Context, status int, code ResCode, msg, data interface{}) {
+	c.JSON(status, &ResponseData{
+		Code:      code,
+		Msg:       msg,
+		Data:      data,
+		RequestID: RequestID(c),
 	})
 }
+
+// NoRoute 未匹配到路由 始终返回 404
+func NoRoute(c *gin.Context) {
+	ResponseErrorWithStatus(c, http.StatusNotFound, CodeNotFound)
+}
+
+// NoMethod 路由存在但请求方法不匹配 始终返回 405
+func NoMethod(c *gin.Context) {
+	ResponseErrorWithStatus(c, http.StatusMethodNotAllowed, CodeMethodNotAllowed)
+}
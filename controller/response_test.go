@@ -0,0 +1,66 @@
+/**
+ * @Author: LiuShuXin
+ * @Description:
+ * @File:  response_test
+ * Software: Goland
+ * @Date: 2026/10/18 22:10
+ */
+
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestResponseErrorStatus(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	defer SetStrictHTTPStatus(false)
+
+	cases := []struct {
+		strict bool
+		code   ResCode
+		want   int
+	}{
+		{false, CodeUserExist, http.StatusOK},
+		{true, CodeUserExist, http.StatusConflict},
+		{true, CodeInvalidParam, http.StatusBadRequest},
+		{true, CodeNeedLogin, http.StatusUnauthorized},
+		{true, ResCode(9999), http.StatusInternalServerError},
+	}
+	for _, c := range cases {
+		SetStrictHTTPStatus(c.strict)
+		w := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(w)
+		ResponseError(ctx, c.code)
+		if w.Code != c.want {
+			t.Errorf("strict=%v code=%d: status = %d, want %d", c.strict, c.code, w.Code, c.want)
+		}
+	}
+}
+
+func TestNoRouteAndNoMethod(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.HandleMethodNotAllowed = true
+	r.NoRoute(NoRoute)
+	r.NoMethod(NoMethod)
+	r.GET("/ping", func(c *gin.Context) {})
+
+	for _, c := range []struct {
+		method, path string
+		want         int
+	}{
+		{http.MethodGet, "/missing", http.StatusNotFound},
+		{http.MethodPost, "/ping", http.StatusMethodNotAllowed},
+	} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(c.method, c.path, nil))
+		if w.Code != c.want {
+			t.Errorf("%s %s: status = %d, want %d", c.method, c.path, w.Code, c.want)
+		}
+	}
+}
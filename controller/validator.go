@@ -0,0 +1,218 @@
+/**
+ * @Author: LiuShuXin
+ * @Description: 请求参数绑定与校验 校验错误按请求语言翻译后以字段为单位返回
+ * @File:  validator
+ * Software: Goland
+ * @Date: 2026/10/19 9:30
+ */
+
+package controller
+
+import (
+	"app-server/pkg/errcode"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/zh"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	enTranslations "github.com/go-playground/validator/v10/translations/en"
+	zhTranslations "github.com/go-playground/validator/v10/translations/zh"
+)
+
+const defaultMaxMemory = 32 << 20 // multipart 表单内存上限 与 gin 保持一致
+
+var (
+	validateOnce sync.Once
+	validate     *validator.Validate
+	uni          *ut.UniversalTranslator
+	validateErr  error
+)
+
+var usernameRegexp = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+// InitValidator 替换 gin 校验器的字段名为 json tag 并注册中英文翻译及自定义校验规则
+// 需在启动时调用 否则直接使用 ShouldBind 等方法校验带有自定义规则的结构体时会 panic 重复调用无副作用
+func InitValidator() error {
+	validateOnce.Do(func() {
+		v, ok := binding.Validator.Engine().(*validator.Validate)
+		if !ok {
+			validateErr = errors.New("binding validator is not go-playground/validator")
+			return
+		}
+		v.RegisterTagNameFunc(func(f reflect.StructField) string {
+			name := strings.SplitN(f.Tag.Get("json"), ",", 2)[0]
+			if name == "-" {
+				return ""
+			}
+			if name == "" {
+				return f.Name
+			}
+			return name
+		})
+
+		zhT, enT := zh.New(), en.New()
+		uni = ut.New(zhT, zhT, enT)
+		trans, _ := uni.GetTranslator("zh")
+		if validateErr = zhTranslations.RegisterDefaultTranslations(v, trans); validateErr != nil {
+			return
+		}
+		trans, _ = uni.GetTranslator("en")
+		if validateErr = enTranslations.RegisterDefaultTranslations(v, trans); validateErr != nil {
+			return
+		}
+		validate = v
+
+		if validateErr = registerValidation("username", func(fl validator.FieldLevel) bool {
+			return usernameRegexp.MatchString(fl.Field().String())
+		}, map[string]string{
+			"zh": "{0}只能包含字母、数字和下划线",
+			"en": "{0} may only contain letters, digits and underscores",
+		}); validateErr != nil {
+			return
+		}
+		// maxbytes 按字节数限制长度 如 bcrypt 只接受不超过 72 字节的密码 而 max 按字符数计算
+		validateErr = registerValidation("maxbytes", func(fl validator.FieldLevel) bool {
+			n, err := strconv.Atoi(fl.Param())
+			return err == nil && len(fl.Field().String()) <= n
+		}, map[string]string{
+			"zh": "{0}长度不能超过{1}个字节",
+			"en": "{0} must be at most {1} bytes long",
+		})
+	})
+	return validateErr
+}
+
+// RegisterValidation 注册自定义校验规则 msgs 为 语言(zh、en) -> 提示模板 {0} 为字段名 {1} 为规则参数
+func RegisterValidation(tag string, fn validator.Func, msgs map[string]string) error {
+	if err := InitValidator(); err != nil {
+		return err
+	}
+	return registerValidation(tag, fn, msgs)
+}
+
+func registerValidation(tag string, fn validator.Func, msgs map[string]string) error {
+	if err := validate.RegisterValidation(tag, fn); err != nil {
+		return err
+	}
+	for locale, msg := range msgs {
+		trans, ok := uni.GetTranslator(locale)
+		if !ok {
+			continue
+		}
+		msg := msg
+		err := validate.RegisterTranslation(tag, trans, func(ut ut.Translator) error {
+			return ut.Add(tag, msg, true)
+		}, func(ut ut.Translator, fe validator.FieldError) string {
+			t, _ := ut.T(tag, fe.Field(), fe.Param())
+			return t
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// BindAndValidate 依次从查询参数(form tag)、请求体(按 Content-Type 解析 JSON 或表单)及路径参数(uri tag)绑定参数
+// 路径参数最后绑定 请求体或查询参数中的同名字段不能覆盖路径中的资源 ID
+// 全部绑定完成后统一校验一次 失败时返回 CodeInvalidParam 的 AppError 其 Details 为 字段 -> 错误提示
+func BindAndValidate(c *gin.Context, obj interface{}) error {
+	if err := InitValidator(); err != nil {
+		return err
+	}
+	if err := bind(c, obj); err != nil {
+		return errcode.New(CodeInvalidParam).WithCause(err)
+	}
+	if err := binding.Validator.ValidateStruct(obj); err != nil {
+		return translateError(c, err)
+	}
+	return nil
+}
+
+func bind(c *gin.Context, obj interface{}) error {
+	if err := binding.MapFormWithTag(obj, c.Request.URL.Query(), "form"); err != nil {
+		return err
+	}
+	if err := bindBody(c, obj); err != nil {
+		return err
+	}
+	if len(c.Params) == 0 {
+		return nil
+	}
+	params := make(map[string][]string, len(c.Params))
+	for _, p := range c.Params {
+		params[p.Key] = []string{p.Value}
+	}
+	return binding.MapFormWithTag(obj, params, "uri")
+}
+
+func bindBody(c *gin.Context, obj interface{}) error {
+	if c.Request.Body == nil || c.Request.Body == http.NoBody ||
+		c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+		return nil
+	}
+
+	switch c.ContentType() {
+	case binding.MIMEPOSTForm:
+		if err := c.Request.ParseForm(); err != nil {
+			return err
+		}
+		return binding.MapFormWithTag(obj, c.Request.PostForm, "form")
+	case binding.MIMEMultipartPOSTForm:
+		if err := c.Request.ParseMultipartForm(defaultMaxMemory); err != nil {
+			return err
+		}
+		return binding.MapFormWithTag(obj, c.Request.MultipartForm.Value, "form")
+	default:
+		dec := json.NewDecoder(c.Request.Body)
+		if binding.EnableDecoderUseNumber {
+			dec.UseNumber()
+		}
+		if binding.EnableDecoderDisallowUnknownFields {
+			dec.DisallowUnknownFields()
+		}
+		// 允许空请求体 是否缺少必填字段交由校验决定
+		if err := dec.Decode(obj); err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+		return nil
+	}
+}
+
+// translateError 将校验错误翻译为当前请求语言的字段级提示
+func translateError(c *gin.Context, err error) error {
+	var errs validator.ValidationErrors
+	if !errors.As(err, &errs) {
+		return errcode.New(CodeInvalidParam).WithCause(err)
+	}
+	lang := "zh"
+	if strings.HasPrefix(Locale(c), "en") {
+		lang = "en"
+	}
+	trans, _ := uni.GetTranslator(lang)
+
+	details := make(map[string]string, len(errs))
+	for _, fe := range errs {
+		details[fieldPath(fe.Namespace())] = fe.Translate(trans)
+	}
+	return errcode.New(CodeInvalidParam).WithCause(err).WithDetails(details)
+}
+
+// fieldPath 去掉命名空间中的结构体名 如 ParamSignUp.re_password -> re_password
+func fieldPath(ns string) string {
+	if i := strings.IndexByte(ns, '.'); i >= 0 {
+		return ns[i+1:]
+	}
+	return ns
+}
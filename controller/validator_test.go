@@ -0,0 +1,164 @@
+/**
+ * @Author: LiuShuXin
+ * @Description:
+ * @File:  validator_test
+ * Software: Goland
+ * @Date: 2026/10/19 10:10
+ */
+
+package controller
+
+import (
+	"app-server/models"
+	"app-server/pkg/errcode"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+func doValidate(t *testing.T, method, target, contentType, body, lang string) (int, ResponseData) {
+	t.Helper()
+	r := gin.New()
+	h := func(c *gin.Context) {
+		var p struct {
+			ID   int64  `uri:"id" json:"id" binding:"required"`
+			Page int    `form:"page" json:"page" binding:"omitempty,min=1"`
+			Name string `form:"name" json:"name" binding:"required,username"`
+		}
+		if err := BindAndValidate(c, &p); err != nil {
+			HandleError(c, err)
+			return
+		}
+		ResponseSuccess(c, p)
+	}
+	r.GET("/items/:id", h)
+	r.POST("/items/:id", h)
+
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	req.Header.Set("Accept-Language", lang)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	var res ResponseData
+	_ = json.Unmarshal(w.Body.Bytes(), &res)
+	return w.Code, res
+}
+
+func TestBindAndValidate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// uri + query + JSON body
+	_, res := doValidate(t, http.MethodPost, "/items/7?page=2", "application/json", `{"name":"tom_1"}`, "")
+	if res.Code != CodeSuccess {
+		t.Fatalf("json: code = %d, data = %v", res.Code, res.Data)
+	}
+	data := res.Data.(map[string]interface{})
+	if data["id"] != float64(7) || data["page"] != float64(2) || data["name"] != "tom_1" {
+		t.Fatalf("json: data = %v", data)
+	}
+
+	// 请求体中的同名字段不能覆盖路径参数
+	_, res = doValidate(t, http.MethodPost, "/items/7", "application/json", `{"id":2,"name":"tom"}`, "")
+	if data, _ := res.Data.(map[string]interface{}); res.Code != CodeSuccess || data["id"] != float64(7) {
+		t.Fatalf("path override: code = %d, data = %v", res.Code, res.Data)
+	}
+
+	// 表单
+	_, res = doValidate(t, http.MethodPost, "/items/7", "application/x-www-form-urlencoded", "name=tom", "")
+	if res.Code != CodeSuccess {
+		t.Fatalf("form: code = %d, data = %v", res.Code, res.Data)
+	}
+
+	// 语法错误的请求体
+	_, res = doValidate(t, http.MethodPost, "/items/7", "application/json", `{"name":`, "")
+	if res.Code != CodeInvalidParam || res.Data != nil {
+		t.Fatalf("bad json: code = %d, data = %v", res.Code, res.Data)
+	}
+}
+
+func TestBindAndValidateTranslation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	_, res := doValidate(t, http.MethodGet, "/items/7?page=-1&name=a-b", "", "", "zh-CN")
+	details, _ := res.Data.(map[string]interface{})
+	if res.Code != CodeInvalidParam || len(details) != 2 {
+		t.Fatalf("zh: code = %d, data = %v", res.Code, res.Data)
+	}
+	if msg := details["name"]; msg != "name只能包含字母、数字和下划线" {
+		t.Errorf("zh: name = %v", msg)
+	}
+	if msg, _ := details["page"].(string); !strings.Contains(msg, "page最小只能为1") {
+		t.Errorf("zh: page = %v", msg)
+	}
+
+	// 字段提示与响应提示使用同一协商结果 需先加载英文语言包
+	if err := errcode.LoadCatalogs("../conf/i18n"); err != nil {
+		t.Fatal(err)
+	}
+	_, res = doValidate(t, http.MethodGet, "/items/7", "", "", "en-US,en;q=0.9")
+	details, _ = res.Data.(map[string]interface{})
+	if msg := details["name"]; msg != "name is a required field" {
+		t.Errorf("en: name = %v", msg)
+	}
+	if res.Msg != "Invalid request parameters" {
+		t.Errorf("en: msg = %v", res.Msg)
+	}
+}
+
+func TestSignUpValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/signup", SignUp)
+
+	body := `{"username":"tom","password":"123456","re_password":"654321"}`
+	req := httptest.NewRequest(http.MethodPost, "/signup", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var res ResponseData
+	_ = json.Unmarshal(w.Body.Bytes(), &res)
+	details, _ := res.Data.(map[string]interface{})
+	if res.Code != CodeInvalidParam || details["re_password"] == nil {
+		t.Fatalf("code = %d, data = %v", res.Code, res.Data)
+	}
+}
+
+func TestInitValidator(t *testing.T) {
+	if err := InitValidator(); err != nil {
+		t.Fatal(err)
+	}
+	// 初始化后不经过 BindAndValidate 也能使用自定义规则
+	p := models.ParamSignUp{Username: "a-b", Password: "123456", RePassword: "123456"}
+	if err := binding.Validator.ValidateStruct(&p); err == nil {
+		t.Error("username rule should reject a-b")
+	}
+}
+
+func TestSignUpMultibytePassword(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/signup", SignUp)
+
+	// 30 个汉字未超过 max=64 个字符 但有 90 字节 超过 bcrypt 的 72 字节上限
+	pwd := strings.Repeat("密", 30)
+	body, _ := json.Marshal(map[string]string{"username": "tom", "password": pwd, "re_password": pwd})
+	req := httptest.NewRequest(http.MethodPost, "/signup", strings.NewReader(string(body)))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var res ResponseData
+	_ = json.Unmarshal(w.Body.Bytes(), &res)
+	details, _ := res.Data.(map[string]interface{})
+	if res.Code != CodeInvalidParam || details["password"] == nil {
+		t.Fatalf("code = %d, data = %v", res.Code, res.Data)
+	}
+}
@@ -0,0 +1,30 @@
+/**
+ * @Author: LiuShuXin
+ * @Description: 数据访问层的公共接口 具体实现见 mysql、memory 等子包
+ * @File:  dao
+ * Software: Goland
+ * @Date: 2026/10/18 16:30
+ */
+
+package dao
+
+import (
+	"app-server/models"
+	"app-server/pkg/errcode"
+	"context"
+)
+
+var (
+	ErrUserExist    = errcode.New(errcode.CodeUserExist)
+	ErrUserNotExist = errcode.New(errcode.CodeUserNotExist)
+)
+
+// UserRepository 用户存储
+type UserRepository interface {
+	// InsertUser 新增用户 用户名已存在时返回 ErrUserExist
+	InsertUser(ctx context.Context, user *models.User) error
+	// GetUserByUsername 按用户名查询 不存在时返回 ErrUserNotExist
+	GetUserByUsername(ctx context.Context, username string) (*models.User, error)
+	// GetUserByID 按用户 ID 查询 不存在时返回 ErrUserNotExist
+	GetUserByID(ctx context.Context, userID int64) (*models.User, error)
+}
@@ -0,0 +1,61 @@
+/**
+ * @Author: LiuShuXin
+ * @Description: 进程内存实现的 repository 用于测试及未接入数据库的本地开发
+ * @File:  user
+ * Software: Goland
+ * @Date: 2026/10/18 16:35
+ */
+
+package memory
+
+import (
+	"app-server/dao"
+	"app-server/models"
+	"context"
+	"sync"
+	"time"
+)
+
+// UserRepo 用户存储 实现 dao.UserRepository
+type UserRepo struct {
+	mu    sync.RWMutex
+	users map[string]models.User // username -> user
+}
+
+func NewUserRepo() *UserRepo {
+	return &UserRepo{users: make(map[string]models.User)}
+}
+
+func (r *UserRepo) InsertUser(_ context.Context, user *models.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.users[user.Username]; ok {
+		return dao.ErrUserExist
+	}
+	if user.CreateTime.IsZero() {
+		user.CreateTime = time.Now()
+	}
+	r.users[user.Username] = *user
+	return nil
+}
+
+func (r *UserRepo) GetUserByUsername(_ context.Context, username string) (*models.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	u, ok := r.users[username]
+	if !ok {
+		return nil, dao.ErrUserNotExist
+	}
+	return &u, nil
+}
+
+func (r *UserRepo) GetUserByID(_ context.Context, userID int64) (*models.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, u := range r.users {
+		if u.UserID == userID {
+			return &u, nil
+		}
+	}
+	return nil, dao.ErrUserNotExist
+}
@@ -0,0 +1,51 @@
+/**
+ * @Author: LiuShuXin
+ * @Description: 将过滤条件转换为 BSON 查询条件
+ * @File:  filter
+ * Software: Goland
+ * @Date: 2026/10/19 14:40
+ */
+
+package mongoDB
+
+import (
+	"app-server/pkg/filter"
+	"regexp"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+var bsonOps = map[filter.Op]string{
+	filter.OpEq:  "$eq",
+	filter.OpNe:  "$ne",
+	filter.OpGt:  "$gt",
+	filter.OpGte: "$gte",
+	filter.OpLt:  "$lt",
+	filter.OpLte: "$lte",
+	filter.OpIn:  "$in",
+}
+
+// BSONFilter 过滤条件对应的查询条件 可直接传给 Repository 的查询方法
+// 同一字段可能出现多个条件 因此多个条件时使用 $and 组合
+func BSONFilter(f filter.Filter) bson.D {
+	conds := make(bson.A, 0, len(f))
+	for _, c := range f {
+		var cond bson.D
+		switch c.Op {
+		case filter.OpLike:
+			cond = bson.D{{Key: c.Column, Value: bson.Regex{Pattern: regexp.QuoteMeta(c.Value.(string)), Options: "i"}}}
+		case filter.OpIn:
+			cond = bson.D{{Key: c.Column, Value: bson.D{{Key: "$in", Value: bson.A(c.Value.([]interface{}))}}}}
+		default:
+			cond = bson.D{{Key: c.Column, Value: bson.D{{Key: bsonOps[c.Op], Value: c.Value}}}}
+		}
+		conds = append(conds, cond)
+	}
+	switch len(conds) {
+	case 0:
+		return bson.D{}
+	case 1:
+		return conds[0].(bson.D)
+	}
+	return bson.D{{Key: "$and", Value: conds}}
+}
@@ -0,0 +1,67 @@
+/**
+ * @Author: LiuShuXin
+ * @Description: 连接池状态指标 驱动未提供连接池统计接口 通过连接池事件计数
+ * @File:  metrics
+ * Software: Goland
+ * @Date: 2026/10/19 22:40
+ */
+
+package mongoDB
+
+import (
+	"sync/atomic"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.mongodb.org/mongo-driver/v2/event"
+)
+
+type poolStats struct {
+	open, inUse, checkoutFailed atomic.Int64
+}
+
+var stats poolStats
+
+func newPoolMonitor() *event.PoolMonitor {
+	return &event.PoolMonitor{Event: func(e *event.PoolEvent) {
+		switch e.Type {
+		case event.ConnectionCreated:
+			stats.open.Add(1)
+		case event.ConnectionClosed:
+			stats.open.Add(-1)
+		case event.ConnectionCheckedOut:
+			stats.inUse.Add(1)
+		case event.ConnectionCheckedIn:
+			stats.inUse.Add(-1)
+		case event.ConnectionCheckOutFailed:
+			stats.checkoutFailed.Add(1)
+		}
+	}}
+}
+
+type poolCollector struct {
+	open, inUse, checkoutFailed *prometheus.Desc
+}
+
+// NewPoolCollector 连接池指标采集器 需在 Init 之后注册到 metrics
+func NewPoolCollector(namespace string) prometheus.Collector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "mongodb_pool", name), help, nil, nil)
+	}
+	return &poolCollector{
+		open:           desc("connections", "Number of open connections in the pool."),
+		inUse:          desc("in_use_connections", "Number of connections currently checked out."),
+		checkoutFailed: desc("checkout_failures_total", "Number of failed connection checkouts."),
+	}
+}
+
+func (p *poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- p.open
+	ch <- p.inUse
+	ch <- p.checkoutFailed
+}
+
+func (p *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	ch <- prometheus.MustNewConstMetric(p.open, prometheus.GaugeValue, float64(stats.open.Load()))
+	ch <- prometheus.MustNewConstMetric(p.inUse, prometheus.GaugeValue, float64(stats.inUse.Load()))
+	ch <- prometheus.MustNewConstMetric(p.checkoutFailed, prometheus.CounterValue, float64(stats.checkoutFailed.Load()))
+}
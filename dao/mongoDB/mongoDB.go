@@ -1,3 +1,90 @@
+/**
+ * @Author: LiuShuXin
+ * @Description: mongoDB连接初始化
+ * @File:  mongoDB
+ * Software: Goland
+ * @Date: 2026/10/18 11:20
+ */
+
 package mongoDB
 
-/*初始化*/
+import (
+	"app-server/settings"
+	"context"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+	"go.mongodb.org/mongo-driver/v2/mongo/readpref"
+)
+
+const defaultConnectTimeout = 10 * time.Second
+
+var (
+	client *mongo.Client
+	db     *mongo.Database
+)
+
+// Init 根据配置初始化 MongoDB 客户端并 ping 主节点
+func Init(cfg *settings.MongoConfig) (err error) {
+	c, err := mongo.Connect(ClientOptions(cfg))
+	if err != nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), connectTimeout(cfg))
+	defer cancel()
+	if err = c.Ping(ctx, readpref.Primary()); err != nil {
+		_ = c.Disconnect(context.Background())
+		return
+	}
+	client = c
+	db = c.Database(cfg.Database)
+	return
+}
+
+// ClientOptions 根据配置构造客户端参数 包括连接超时、认证信息、链路追踪及连接池监控
+func ClientOptions(cfg *settings.MongoConfig) *options.ClientOptions {
+	opts := options.Client().ApplyURI(cfg.Uri).SetConnectTimeout(connectTimeout(cfg)).
+		SetMonitor(newCommandMonitor()).
+		SetPoolMonitor(newPoolMonitor())
+
+	// SCRAM 等机制必须提供用户名 未配置用户名时视为不开启认证
+	cred := cfg.Credential
+	if cred.Username != "" || (cred.AuthMechanism != "" && !strings.HasPrefix(cred.AuthMechanism, "SCRAM")) {
+		opts.SetAuth(options.Credential{
+			AuthMechanism: cred.AuthMechanism,
+			AuthSource:    cred.AuthSource,
+			Username:      cred.Username,
+			Password:      cred.Password,
+			PasswordSet:   cred.PasswordSet,
+		})
+	}
+	return opts
+}
+
+func connectTimeout(cfg *settings.MongoConfig) time.Duration {
+	if cfg.ConnectTimout <= 0 {
+		return defaultConnectTimeout
+	}
+	return time.Duration(cfg.ConnectTimout) * time.Second
+}
+
+// DB 获取 Database 对应配置中的 database
+func DB() *mongo.Database {
+	return db
+}
+
+// Ping 检查连接是否可用
+func Ping(ctx context.Context) error {
+	return client.Ping(ctx, readpref.Primary())
+}
+
+// Close 断开连接
+func Close(ctx context.Context) error {
+	if client == nil {
+		return nil
+	}
+	return client.Disconnect(ctx)
+}
@@ -0,0 +1,91 @@
+/**
+ * @Author: LiuShuXin
+ * @Description:
+ * @File:  mongoDB_test
+ * Software: Goland
+ * @Date: 2026/10/18 11:50
+ */
+
+package mongoDB
+
+import (
+	"app-server/pkg/filter"
+	"app-server/settings"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestClientOptions(t *testing.T) {
+	cfg := &settings.MongoConfig{
+		Uri:           "mongodb://127.0.0.1:27017",
+		ConnectTimout: 3,
+		Database:      "app",
+		Credential: settings.Credential{
+			Username:      "root",
+			Password:      "secret",
+			AuthMechanism: "SCRAM-SHA-256",
+			AuthSource:    "admin",
+			PasswordSet:   true,
+		},
+	}
+	opts := ClientOptions(cfg)
+	if opts.ConnectTimeout == nil || *opts.ConnectTimeout != 3*time.Second {
+		t.Errorf("ConnectTimeout = %v, want 3s", opts.ConnectTimeout)
+	}
+	if opts.Auth == nil {
+		t.Fatal("Auth should be set")
+	}
+	if opts.Auth.Username != "root" || opts.Auth.AuthSource != "admin" || opts.Auth.AuthMechanism != "SCRAM-SHA-256" {
+		t.Errorf("Auth = %+v", opts.Auth)
+	}
+}
+
+func TestClientOptionsWithoutCredential(t *testing.T) {
+	cfg := &settings.MongoConfig{
+		Uri: "mongodb://127.0.0.1:27017",
+		Credential: settings.Credential{
+			AuthMechanism: "SCRAM-SHA-1",
+			AuthSource:    "admin",
+		},
+	}
+	opts := ClientOptions(cfg)
+	if opts.Auth != nil {
+		t.Errorf("Auth should be nil without username, got %+v", opts.Auth)
+	}
+	if *opts.ConnectTimeout != defaultConnectTimeout {
+		t.Errorf("ConnectTimeout = %v, want %v", *opts.ConnectTimeout, defaultConnectTimeout)
+	}
+}
+
+func TestBSONFilter(t *testing.T) {
+	if got := BSONFilter(nil); len(got) != 0 {
+		t.Errorf("BSONFilter(nil) = %v", got)
+	}
+
+	one := BSONFilter(filter.Filter{{Column: "status", Op: filter.OpNe, Value: "deleted"}})
+	want := bson.D{{Key: "status", Value: bson.D{{Key: "$ne", Value: "deleted"}}}}
+	if !reflect.DeepEqual(one, want) {
+		t.Errorf("single = %v, want %v", one, want)
+	}
+
+	f := filter.Filter{
+		{Column: "age", Op: filter.OpGte, Value: int64(18)},
+		{Column: "age", Op: filter.OpIn, Value: []interface{}{int64(18), int64(20)}},
+		{Column: "name", Op: filter.OpLike, Value: "a.b"},
+	}
+	got := BSONFilter(f)
+	if len(got) != 1 || got[0].Key != "$and" {
+		t.Fatalf("multi = %v", got)
+	}
+	conds := got[0].Value.(bson.A)
+	if len(conds) != 3 {
+		t.Fatalf("conds = %v", conds)
+	}
+	re := conds[2].(bson.D)[0].Value.(bson.Regex)
+	if re.Pattern != `a\.b` || re.Options != "i" {
+		t.Errorf("like = %v", re)
+	}
+}
@@ -0,0 +1,164 @@
+/**
+ * @Author: LiuShuXin
+ * @Description: 通用的集合操作
+ * @File:  repository
+ * Software: Goland
+ * @Date: 2026/10/18 11:35
+ */
+
+package mongoDB
+
+import (
+	"app-server/pkg/pagination"
+	"context"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// ErrNotFound 未查询到文档
+var ErrNotFound = mongo.ErrNoDocuments
+
+// Repository 泛型集合仓库 T 为文档对应的结构体
+type Repository[T any] struct {
+	coll *mongo.Collection
+}
+
+// NewRepository 创建集合仓库 需在 Init 之后调用
+func NewRepository[T any](collection string) *Repository[T] {
+	return &Repository[T]{coll: db.Collection(collection)}
+}
+
+// Collection 获取原始集合 用于仓库未覆盖的操作
+func (r *Repository[T]) Collection() *mongo.Collection {
+	return r.coll
+}
+
+// FindOne 查询单个文档 未找到时返回 ErrNotFound
+func (r *Repository[T]) FindOne(ctx context.Context, filter any) (*T, error) {
+	doc := new(T)
+	if err := r.coll.FindOne(ctx, orEmpty(filter)).Decode(doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// Find 查询多个文档
+func (r *Repository[T]) Find(ctx context.Context, filter any, opts ...options.Lister[options.FindOptions]) ([]T, error) {
+	cur, err := r.coll.Find(ctx, orEmpty(filter), opts...)
+	if err != nil {
+		return nil, err
+	}
+	docs := make([]T, 0)
+	if err = cur.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+// Insert 插入单个文档 返回文档 _id
+func (r *Repository[T]) Insert(ctx context.Context, doc *T) (any, error) {
+	res, err := r.coll.InsertOne(ctx, doc)
+	if err != nil {
+		return nil, err
+	}
+	return res.InsertedID, nil
+}
+
+// Update 更新匹配的第一个文档 返回匹配数量
+func (r *Repository[T]) Update(ctx context.Context, filter, update any) (int64, error) {
+	res, err := r.coll.UpdateOne(ctx, orEmpty(filter), update)
+	if err != nil {
+		return 0, err
+	}
+	return res.MatchedCount, nil
+}
+
+// Delete 删除匹配的第一个文档 返回删除数量
+func (r *Repository[T]) Delete(ctx context.Context, filter any) (int64, error) {
+	res, err := r.coll.DeleteOne(ctx, orEmpty(filter))
+	if err != nil {
+		return 0, err
+	}
+	return res.DeletedCount, nil
+}
+
+// Paginate 分页查询 偏移分页同时返回总数 游标分页按 Params.Sort 字段(雪花 ID)翻页
+func (r *Repository[T]) Paginate(ctx context.Context, filter any, p pagination.Params) (*pagination.Page[T], error) {
+	filter = orEmpty(filter)
+	opts := options.Find().SetLimit(int64(p.Limit()))
+	if p.Sort != "" {
+		dir := 1
+		if p.Desc {
+			dir = -1
+		}
+		opts.SetSort(bson.D{{Key: p.Sort, Value: dir}})
+	}
+
+	if p.Mode == pagination.ModeCursor {
+		if p.Cursor != 0 {
+			op := "$gt"
+			if p.Desc {
+				op = "$lt"
+			}
+			filter = bson.D{{Key: "$and", Value: bson.A{filter, bson.D{{Key: p.Sort, Value: bson.D{{Key: op, Value: p.Cursor}}}}}}}
+		}
+		return r.findByCursor(ctx, filter, p, opts)
+	}
+
+	total, err := r.coll.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	docs, err := r.Find(ctx, filter, opts.SetSkip(int64(p.Offset())))
+	if err != nil {
+		return nil, err
+	}
+	return pagination.NewOffsetPage(docs, total, p), nil
+}
+
+// findByCursor 查询的同时记录每个文档的游标字段 无需调用方提供取 ID 的方法
+func (r *Repository[T]) findByCursor(ctx context.Context, filter any, p pagination.Params, opts *options.FindOptionsBuilder) (*pagination.Page[T], error) {
+	cur, err := r.coll.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	type item struct {
+		doc T
+		id  int64
+	}
+	var items []item
+	for cur.Next(ctx) {
+		var it item
+		if err = cur.Decode(&it.doc); err != nil {
+			return nil, err
+		}
+		it.id, _ = cur.Current.Lookup(p.Sort).AsInt64OK()
+		items = append(items, it)
+	}
+	if err = cur.Err(); err != nil {
+		return nil, err
+	}
+	page := pagination.NewCursorPage(items, p, func(it item) int64 { return it.id })
+	docs := make([]T, len(page.List))
+	for i, it := range page.List {
+		docs[i] = it.doc
+	}
+	return &pagination.Page[T]{
+		List:       docs,
+		Total:      page.Total,
+		PageSize:   page.PageSize,
+		NextCursor: page.NextCursor,
+		HasMore:    page.HasMore,
+	}, nil
+}
+
+func orEmpty(filter any) any {
+	if filter == nil {
+		return bson.D{}
+	}
+	return filter
+}
@@ -0,0 +1,150 @@
+/**
+ * @Author: LiuShuXin
+ * @Description:
+ * @File:  repository_test
+ * Software: Goland
+ * @Date: 2026/10/21 10:00
+ */
+
+package mongoDB
+
+import (
+	"app-server/pkg/pagination"
+	"context"
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+	"go.mongodb.org/mongo-driver/v2/x/mongo/driver/drivertest"
+)
+
+type item struct {
+	ID   int64  `bson:"_id"`
+	Name string `bson:"name"`
+}
+
+// newMockRepository 使用模拟部署创建仓库 服务端按顺序返回 AddResponses 添加的响应
+func newMockRepository(t *testing.T) (*Repository[item], *drivertest.MockDeployment) {
+	t.Helper()
+	md := drivertest.NewMockDeployment()
+	opts := options.Client()
+	opts.Deployment = md
+	client, err := mongo.Connect(opts)
+	if err != nil {
+		t.Fatalf("connect mock deployment: %v", err)
+	}
+	t.Cleanup(func() { _ = client.Disconnect(context.Background()) })
+	return &Repository[item]{coll: client.Database("test").Collection("items")}, md
+}
+
+// cursorResponse 单批次返回全部文档的查询响应
+func cursorResponse(docs ...bson.D) bson.D {
+	batch := make(bson.A, len(docs))
+	for i, d := range docs {
+		batch[i] = d
+	}
+	return bson.D{
+		{Key: "ok", Value: 1},
+		{Key: "cursor", Value: bson.D{
+			{Key: "id", Value: int64(0)},
+			{Key: "ns", Value: "test.items"},
+			{Key: "firstBatch", Value: batch},
+		}},
+	}
+}
+
+func doc(id int64, name string) bson.D {
+	return bson.D{{Key: "_id", Value: id}, {Key: "name", Value: name}}
+}
+
+func TestRepositoryFindOneNotFound(t *testing.T) {
+	repo, md := newMockRepository(t)
+	md.AddResponses(cursorResponse())
+	_, err := repo.FindOne(context.Background(), bson.D{{Key: "_id", Value: int64(1)}})
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("err = %v, want ErrNotFound", err)
+	}
+
+	md.AddResponses(cursorResponse(doc(1, "a")))
+	got, err := repo.FindOne(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ID != 1 || got.Name != "a" {
+		t.Errorf("FindOne = %+v", got)
+	}
+}
+
+func TestRepositoryPaginateOffset(t *testing.T) {
+	repo, md := newMockRepository(t)
+	// CountDocuments 以聚合实现 返回 {n: 总数}
+	md.AddResponses(
+		cursorResponse(bson.D{{Key: "n", Value: int32(5)}}),
+		cursorResponse(doc(3, "c"), doc(4, "d")),
+	)
+	p := pagination.Params{Mode: pagination.ModeOffset, Page: 2, PageSize: 2, Sort: "_id"}
+	page, err := repo.Paginate(context.Background(), nil, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.Total != 5 {
+		t.Errorf("Total = %d, want 5", page.Total)
+	}
+	if len(page.List) != 2 || page.List[0].ID != 3 || page.List[1].ID != 4 {
+		t.Errorf("List = %+v", page.List)
+	}
+	if !page.HasMore || page.NextCursor != "" {
+		t.Errorf("HasMore = %v, NextCursor = %q, want true and no cursor", page.HasMore, page.NextCursor)
+	}
+}
+
+func TestRepositoryPaginateCursor(t *testing.T) {
+	repo, md := newMockRepository(t)
+	p := pagination.Params{Mode: pagination.ModeCursor, PageSize: 2, Sort: "_id", Desc: true, Cursor: 10}
+
+	// 多取的一条表示还有下一页 游标为本页最后一条的 _id
+	md.AddResponses(cursorResponse(doc(9, "i"), doc(8, "h"), doc(7, "g")))
+	page, err := repo.Paginate(context.Background(), bson.D{{Key: "name", Value: bson.D{{Key: "$ne", Value: ""}}}}, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.List) != 2 || page.List[0].ID != 9 || page.List[1].ID != 8 {
+		t.Errorf("List = %+v", page.List)
+	}
+	if !page.HasMore || page.NextCursor != "8" {
+		t.Errorf("HasMore = %v, NextCursor = %q, want true, 8", page.HasMore, page.NextCursor)
+	}
+	if page.Total != -1 {
+		t.Errorf("Total = %d, want -1", page.Total)
+	}
+
+	// 最后一页
+	p.Cursor = 8
+	md.AddResponses(cursorResponse(doc(7, "g")))
+	page, err = repo.Paginate(context.Background(), nil, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.List) != 1 || page.HasMore {
+		t.Errorf("last page = %+v", page)
+	}
+}
+
+func TestRepositoryUpdateDelete(t *testing.T) {
+	repo, md := newMockRepository(t)
+	md.AddResponses(
+		bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: int32(1)}, {Key: "nModified", Value: int32(1)}},
+		bson.D{{Key: "ok", Value: 1}, {Key: "n", Value: int32(0)}},
+	)
+	matched, err := repo.Update(context.Background(), bson.D{{Key: "_id", Value: int64(1)}},
+		bson.D{{Key: "$set", Value: bson.D{{Key: "name", Value: "b"}}}})
+	if err != nil || matched != 1 {
+		t.Errorf("Update = %d, %v, want 1", matched, err)
+	}
+	deleted, err := repo.Delete(context.Background(), bson.D{{Key: "_id", Value: int64(2)}})
+	if err != nil || deleted != 0 {
+		t.Errorf("Delete = %d, %v, want 0", deleted, err)
+	}
+}
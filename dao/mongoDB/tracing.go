@@ -0,0 +1,64 @@
+/**
+ * @Author: LiuShuXin
+ * @Description: 通过命令监听为 MongoDB 命令创建 span 仓库及直接使用原始集合的调用均会记录
+ * @File:  tracing
+ * Software: Goland
+ * @Date: 2026/10/19 20:50
+ */
+
+package mongoDB
+
+import (
+	"app-server/pkg/tracing"
+	"context"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/v2/event"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// commandTracer 命令开始时创建 span 结束时按 RequestID 找回并结束
+type commandTracer struct {
+	spans sync.Map // RequestID -> trace.Span
+}
+
+func newCommandMonitor() *event.CommandMonitor {
+	t := new(commandTracer)
+	return &event.CommandMonitor{
+		Started:   t.started,
+		Succeeded: t.succeeded,
+		Failed:    t.failed,
+	}
+}
+
+func (t *commandTracer) started(ctx context.Context, e *event.CommandStartedEvent) {
+	attrs := append(make([]attribute.KeyValue, 0, 4),
+		semconv.DBSystemMongoDB,
+		semconv.DBOperationName(e.CommandName),
+		semconv.DBNamespace(e.DatabaseName),
+	)
+	// 命令文档中第一个字段的值为集合名 如 {find: "user", ...}
+	if elem, err := e.Command.IndexErr(0); err == nil {
+		if coll, ok := elem.Value().StringValueOK(); ok {
+			attrs = append(attrs, semconv.DBCollectionName(coll))
+		}
+	}
+	_, span := tracing.Start(ctx, "mongodb."+e.CommandName, attrs...)
+	t.spans.Store(e.RequestID, span)
+}
+
+func (t *commandTracer) succeeded(_ context.Context, e *event.CommandSucceededEvent) {
+	t.end(e.RequestID, nil)
+}
+
+func (t *commandTracer) failed(_ context.Context, e *event.CommandFailedEvent) {
+	t.end(e.RequestID, e.Failure)
+}
+
+func (t *commandTracer) end(requestID int64, err error) {
+	if v, ok := t.spans.LoadAndDelete(requestID); ok {
+		tracing.End(v.(trace.Span), err)
+	}
+}
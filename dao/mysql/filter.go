@@ -0,0 +1,64 @@
+/**
+ * @Author: LiuShuXin
+ * @Description: 将过滤条件转换为 SQL 条件 列名来自 filter.Schema 白名单 取值均使用占位符
+ * @File:  filter
+ * Software: Goland
+ * @Date: 2026/10/19 14:30
+ */
+
+package mysql
+
+import (
+	"app-server/pkg/filter"
+	"strings"
+)
+
+var sqlOps = map[filter.Op]string{
+	filter.OpEq:  " = ?",
+	filter.OpNe:  " <> ?",
+	filter.OpGt:  " > ?",
+	filter.OpGte: " >= ?",
+	filter.OpLt:  " < ?",
+	filter.OpLte: " <= ?",
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// WhereClause 过滤条件对应的 SQL 条件 不含 where 关键字 需与其他条件组合时使用 Where
+func WhereClause(f filter.Filter) (string, []interface{}) {
+	conds := make([]string, 0, len(f))
+	var args []interface{}
+	for _, c := range f {
+		switch c.Op {
+		case filter.OpLike:
+			conds = append(conds, c.Column+" like ?")
+			args = append(args, "%"+likeEscaper.Replace(c.Value.(string))+"%")
+		case filter.OpIn:
+			values := c.Value.([]interface{})
+			conds = append(conds, c.Column+" in (?"+strings.Repeat(",?", len(values)-1)+")")
+			args = append(args, values...)
+		default:
+			conds = append(conds, c.Column+sqlOps[c.Op])
+			args = append(args, c.Value)
+		}
+	}
+	return strings.Join(conds, " and "), args
+}
+
+// Where 组合多个条件 忽略空条件 全部为空时返回空字符串
+//
+//	cond, args := mysql.WhereClause(f)
+//	keyset, kargs := mysql.KeysetCondition(p)
+//	sqlStr := `select ... from ` + mysql.Table("user") + mysql.Where(cond, keyset)
+func Where(conds ...string) string {
+	parts := make([]string, 0, len(conds))
+	for _, c := range conds {
+		if c != "" {
+			parts = append(parts, "("+c+")")
+		}
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return " where " + strings.Join(parts, " and ")
+}
@@ -7,3 +7,94 @@
  */
 
 package mysql
+
+import (
+	"app-server/pkg/tracing"
+	"app-server/settings"
+	"context"
+	"net"
+	"strconv"
+	"time"
+
+	gomysql "github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const pingTimeout = 5 * time.Second
+
+var (
+	db     *sqlx.DB
+	prefix string // 表名前缀
+)
+
+// Init 根据配置初始化 MySQL 连接池
+func Init(cfg *settings.MySQLConfig) (err error) {
+	return Open("mysql", DSN(cfg), cfg)
+}
+
+// DSN 根据配置拼接连接串
+func DSN(cfg *settings.MySQLConfig) string {
+	c := gomysql.NewConfig()
+	c.User = cfg.User
+	c.Passwd = cfg.Password
+	c.Net = "tcp"
+	c.Addr = net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port))
+	c.DBName = cfg.DBName
+	c.ParseTime = cfg.ParseTime
+	c.Loc = time.Local
+	if cfg.Charset != "" {
+		c.Params = map[string]string{"charset": cfg.Charset}
+	}
+	return c.FormatDSN()
+}
+
+// Open 使用指定驱动建立连接池并 ping 一次
+// 单测中可传入替身驱动，无需真实的 MySQL
+func Open(driverName, dsn string, cfg *settings.MySQLConfig) (err error) {
+	d, err := sqlx.Open(driverName, dsn)
+	if err != nil {
+		return
+	}
+	d.SetMaxOpenConns(cfg.MaxOpenConns)
+	d.SetMaxIdleConns(cfg.MaxIdleConns)
+
+	ctx, cancel := context.WithTimeout(context.Background(), pingTimeout)
+	defer cancel()
+	if err = d.PingContext(ctx); err != nil {
+		_ = d.Close()
+		return
+	}
+	db = d
+	prefix = cfg.TablePrefix
+	return
+}
+
+// DB 获取连接池 供各 repository 使用
+func DB() *sqlx.DB {
+	return db
+}
+
+// Table 返回加上前缀后的表名
+func Table(name string) string {
+	return prefix + name
+}
+
+// Ping 检查连接是否可用
+func Ping(ctx context.Context) error {
+	return db.PingContext(ctx)
+}
+
+// Close 关闭连接池
+func Close() error {
+	if db == nil {
+		return nil
+	}
+	return db.Close()
+}
+
+// startSpan 为 SQL 语句创建 span 语句中只有占位符 不含参数值
+func startSpan(ctx context.Context, name, query string) (context.Context, trace.Span) {
+	return tracing.Start(ctx, name, semconv.DBSystemMySQL, semconv.DBQueryText(query))
+}
@@ -0,0 +1,133 @@
+/**
+ * @Author: LiuShuXin
+ * @Description:
+ * @File:  mysql_test
+ * Software: Goland
+ * @Date: 2026/10/18 10:40
+ */
+
+package mysql
+
+import (
+	"app-server/pkg/filter"
+	"app-server/pkg/pagination"
+	"app-server/settings"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// fakeDriver 进程内的 MySQL 替身 只实现建连与 ping
+type fakeDriver struct{}
+
+func (fakeDriver) Open(dsn string) (driver.Conn, error) {
+	if strings.Contains(dsn, "unreachable") {
+		return nil, errors.New("dial tcp: connection refused")
+	}
+	return fakeConn{}, nil
+}
+
+type fakeConn struct{}
+
+func (fakeConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not implemented") }
+func (fakeConn) Close() error                        { return nil }
+func (fakeConn) Begin() (driver.Tx, error)           { return nil, errors.New("not implemented") }
+func (fakeConn) Ping(context.Context) error          { return nil }
+
+func init() {
+	sql.Register("fakemysql", fakeDriver{})
+}
+
+func testConfig() *settings.MySQLConfig {
+	return &settings.MySQLConfig{
+		Host:         "127.0.0.1",
+		Port:         3306,
+		User:         "root",
+		Password:     "p@ss:word",
+		DBName:       "app",
+		ParseTime:    true,
+		Charset:      "utf8mb4",
+		MaxOpenConns: 20,
+		MaxIdleConns: 5,
+		TablePrefix:  "t_",
+	}
+}
+
+func TestDSN(t *testing.T) {
+	dsn := DSN(testConfig())
+	for _, want := range []string{"root:p@ss:word@tcp(127.0.0.1:3306)/app", "charset=utf8mb4", "parseTime=true"} {
+		if !strings.Contains(dsn, want) {
+			t.Errorf("DSN() = %s, want contains %s", dsn, want)
+		}
+	}
+}
+
+func TestOpen(t *testing.T) {
+	cfg := testConfig()
+	if err := Open("fakemysql", DSN(cfg), cfg); err != nil {
+		t.Fatalf("Open() err: %v", err)
+	}
+	defer Close()
+
+	if got := DB().Stats().MaxOpenConnections; got != cfg.MaxOpenConns {
+		t.Errorf("MaxOpenConnections = %d, want %d", got, cfg.MaxOpenConns)
+	}
+	if got := Table("user"); got != "t_user" {
+		t.Errorf("Table() = %s, want t_user", got)
+	}
+	if err := Ping(context.Background()); err != nil {
+		t.Errorf("Ping() err: %v", err)
+	}
+}
+
+func TestOpenPingFailed(t *testing.T) {
+	cfg := testConfig()
+	if err := Open("fakemysql", "unreachable", cfg); err == nil {
+		t.Fatal("Open() should fail when ping failed")
+	}
+}
+
+func TestPageClause(t *testing.T) {
+	p := pagination.Params{Mode: pagination.ModeOffset, Page: 3, PageSize: 10, Sort: "create_time", Desc: true}
+	if cond, _ := KeysetCondition(p); cond != "" {
+		t.Errorf("offset KeysetCondition() = %q", cond)
+	}
+	clause, args := OrderLimit(p)
+	if clause != " order by create_time desc limit ? offset ?" || args[0] != 10 || args[1] != 20 {
+		t.Errorf("offset OrderLimit() = %q %v", clause, args)
+	}
+
+	p = pagination.Params{Mode: pagination.ModeCursor, PageSize: 10, Sort: "user_id", Desc: true, Cursor: 42}
+	cond, args := KeysetCondition(p)
+	if cond != "user_id < ?" || args[0] != int64(42) {
+		t.Errorf("cursor KeysetCondition() = %q %v", cond, args)
+	}
+	if clause, args = OrderLimit(p); clause != " order by user_id desc limit ?" || args[0] != 11 {
+		t.Errorf("cursor OrderLimit() = %q %v", clause, args)
+	}
+}
+
+func TestWhereClause(t *testing.T) {
+	f := filter.Filter{
+		{Column: "status", Op: filter.OpEq, Value: "active"},
+		{Column: "age", Op: filter.OpIn, Value: []interface{}{int64(18), int64(20)}},
+		{Column: "username", Op: filter.OpLike, Value: "a_b%"},
+	}
+	cond, args := WhereClause(f)
+	if cond != "status = ? and age in (?,?) and username like ?" {
+		t.Errorf("WhereClause() = %q", cond)
+	}
+	if len(args) != 4 || args[3] != `%a\_b\%%` {
+		t.Errorf("args = %v", args)
+	}
+
+	if got := Where(cond, "", "user_id < ?"); got != " where ("+cond+") and (user_id < ?)" {
+		t.Errorf("Where() = %q", got)
+	}
+	if got := Where("", ""); got != "" {
+		t.Errorf("Where(empty) = %q", got)
+	}
+}
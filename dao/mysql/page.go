@@ -0,0 +1,38 @@
+/**
+ * @Author: LiuShuXin
+ * @Description: 分页查询语句拼接 列名来自 pagination.Options 白名单 可直接拼入语句
+ * @File:  page
+ * Software: Goland
+ * @Date: 2026/10/19 11:40
+ */
+
+package mysql
+
+import "app-server/pkg/pagination"
+
+// KeysetCondition 游标分页条件 如 user_id < ? 非游标分页或第一页时返回空字符串
+func KeysetCondition(p pagination.Params) (string, []interface{}) {
+	if p.Mode != pagination.ModeCursor || p.Cursor == 0 {
+		return "", nil
+	}
+	op := " > ?"
+	if p.Desc {
+		op = " < ?"
+	}
+	return p.Sort + op, []interface{}{p.Cursor}
+}
+
+// OrderLimit 排序及分页子句 如 order by create_time desc limit ? offset ?
+func OrderLimit(p pagination.Params) (string, []interface{}) {
+	var clause string
+	if p.Sort != "" {
+		clause = " order by " + p.Sort
+		if p.Desc {
+			clause += " desc"
+		}
+	}
+	if p.Mode == pagination.ModeCursor {
+		return clause + " limit ?", []interface{}{p.Limit()}
+	}
+	return clause + " limit ? offset ?", []interface{}{p.Limit(), p.Offset()}
+}
@@ -0,0 +1,102 @@
+/**
+ * @Author: LiuShuXin
+ * @Description: 用户表操作
+ * @File:  user
+ * Software: Goland
+ * @Date: 2026/10/18 16:40
+ */
+
+package mysql
+
+import (
+	"app-server/dao"
+	"app-server/models"
+	"app-server/pkg/tracing"
+	"context"
+	"database/sql"
+	"errors"
+
+	gomysql "github.com/go-sql-driver/mysql"
+)
+
+/*
+CREATE TABLE `t_user` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `user_id` bigint(20) NOT NULL,
+  `username` varchar(64) COLLATE utf8mb4_general_ci NOT NULL,
+  `password` varchar(255) COLLATE utf8mb4_general_ci NOT NULL,
+  `role` varchar(32) COLLATE utf8mb4_general_ci NOT NULL DEFAULT 'user',
+  `disabled` tinyint(1) NOT NULL DEFAULT 0,
+  `create_time` timestamp NULL DEFAULT CURRENT_TIMESTAMP,
+  `update_time` timestamp NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_username` (`username`) USING BTREE,
+  UNIQUE KEY `idx_user_id` (`user_id`) USING BTREE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
+*/
+
+const (
+	errDuplicateEntry = 1062
+
+	userColumns = `user_id, username, password, role, disabled, create_time`
+)
+
+// UserRepo 用户存储 实现 dao.UserRepository
+type UserRepo struct{}
+
+func NewUserRepo() *UserRepo {
+	return &UserRepo{}
+}
+
+func (UserRepo) InsertUser(ctx context.Context, user *models.User) (err error) {
+	sqlStr := `insert into ` + Table("user") + `(user_id, username, password, role) values(?,?,?,?)`
+	ctx, span := startSpan(ctx, "mysql.InsertUser", sqlStr)
+	defer func() { tracing.End(span, err) }()
+
+	_, err = db.ExecContext(ctx, sqlStr, user.UserID, user.Username, user.Password, user.Role)
+	var me *gomysql.MySQLError
+	if errors.As(err, &me) && me.Number == errDuplicateEntry {
+		return dao.ErrUserExist
+	}
+	return err
+}
+
+func (UserRepo) GetUserByUsername(ctx context.Context, username string) (_ *models.User, err error) {
+	user := new(models.User)
+	sqlStr := `select ` + userColumns + ` from ` + Table("user") + ` where username = ?`
+	ctx, span := startSpan(ctx, "mysql.GetUserByUsername", sqlStr)
+	defer func() { tracing.End(span, ignoreNotExist(err)) }()
+
+	err = db.GetContext(ctx, user, sqlStr, username)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, dao.ErrUserNotExist
+	}
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (UserRepo) GetUserByID(ctx context.Context, userID int64) (_ *models.User, err error) {
+	user := new(models.User)
+	sqlStr := `select ` + userColumns + ` from ` + Table("user") + ` where user_id = ?`
+	ctx, span := startSpan(ctx, "mysql.GetUserByID", sqlStr)
+	defer func() { tracing.End(span, ignoreNotExist(err)) }()
+
+	err = db.GetContext(ctx, user, sqlStr, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, dao.ErrUserNotExist
+	}
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// ignoreNotExist 记录不存在属于正常的业务结果 不标记 span 为失败
+func ignoreNotExist(err error) error {
+	if errors.Is(err, dao.ErrUserNotExist) {
+		return nil
+	}
+	return err
+}
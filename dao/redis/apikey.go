@@ -0,0 +1,54 @@
+/**
+ * @Author: LiuShuXin
+ * @Description: API Key 存储
+ * @File:  apikey
+ * Software: Goland
+ * @Date: 2026/10/18 19:30
+ */
+
+package redis
+
+import (
+	"app-server/pkg/apikey"
+	"context"
+	"time"
+)
+
+// APIKeyStore API Key 存储 实现 apikey.Store
+type APIKeyStore struct{}
+
+func NewAPIKeyStore() *APIKeyStore {
+	return &APIKeyStore{}
+}
+
+func (APIKeyStore) key(hash string) string {
+	return "apikey:" + hash
+}
+
+func (s APIKeyStore) Get(ctx context.Context, hash string) (*apikey.Key, error) {
+	k, err := GetJSON[apikey.Key](ctx, s.key(hash))
+	if IsNil(err) {
+		return nil, apikey.ErrInvalidKey
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &k, nil
+}
+
+// Save 保存 API Key 设置了过期时间的 key 到期后自动删除
+func (s APIKeyStore) Save(ctx context.Context, k apikey.Key) error {
+	var ttl time.Duration
+	if !k.ExpiresAt.IsZero() {
+		if ttl = time.Until(k.ExpiresAt); ttl <= 0 {
+			return apikey.ErrExpiredKey
+		}
+	}
+	return SetJSON(ctx, s.key(k.Hash), k, ttl)
+}
+
+// Delete 删除 API Key
+func (s APIKeyStore) Delete(ctx context.Context, hash string) error {
+	_, err := Del(ctx, s.key(hash))
+	return err
+}
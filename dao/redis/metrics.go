@@ -0,0 +1,55 @@
+/**
+ * @Author: LiuShuXin
+ * @Description: 连接池状态指标
+ * @File:  metrics
+ * Software: Goland
+ * @Date: 2026/10/19 22:30
+ */
+
+package redis
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// poolCollector 采集时读取 PoolStats 无需定时刷新
+type poolCollector struct {
+	hits, misses, timeouts *prometheus.Desc
+	totalConns, idleConns  *prometheus.Desc
+	staleConns             *prometheus.Desc
+}
+
+// NewPoolCollector 连接池指标采集器 需在 Init 之后注册到 metrics
+func NewPoolCollector(namespace string) prometheus.Collector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "redis_pool", name), help, nil, nil)
+	}
+	return &poolCollector{
+		hits:       desc("hits_total", "Number of times a free connection was found in the pool."),
+		misses:     desc("misses_total", "Number of times a free connection was not found in the pool."),
+		timeouts:   desc("timeouts_total", "Number of times a wait timeout occurred."),
+		totalConns: desc("connections", "Number of total connections in the pool."),
+		idleConns:  desc("idle_connections", "Number of idle connections in the pool."),
+		staleConns: desc("stale_connections_total", "Number of stale connections removed from the pool."),
+	}
+}
+
+func (p *poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- p.hits
+	ch <- p.misses
+	ch <- p.timeouts
+	ch <- p.totalConns
+	ch <- p.idleConns
+	ch <- p.staleConns
+}
+
+func (p *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	if client == nil {
+		return
+	}
+	s := client.PoolStats()
+	ch <- prometheus.MustNewConstMetric(p.hits, prometheus.CounterValue, float64(s.Hits))
+	ch <- prometheus.MustNewConstMetric(p.misses, prometheus.CounterValue, float64(s.Misses))
+	ch <- prometheus.MustNewConstMetric(p.timeouts, prometheus.CounterValue, float64(s.Timeouts))
+	ch <- prometheus.MustNewConstMetric(p.totalConns, prometheus.GaugeValue, float64(s.TotalConns))
+	ch <- prometheus.MustNewConstMetric(p.idleConns, prometheus.GaugeValue, float64(s.IdleConns))
+	ch <- prometheus.MustNewConstMetric(p.staleConns, prometheus.CounterValue, float64(s.StaleConns))
+}
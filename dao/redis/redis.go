@@ -7,3 +7,147 @@
  */
 
 package redis
+
+import (
+	"app-server/settings"
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const pingTimeout = 5 * time.Second
+
+// ErrNil key 不存在
+var ErrNil = redis.Nil
+
+var (
+	client *redis.Client
+	prefix string // key 前缀 一般为服务名
+)
+
+// Init 根据配置初始化 Redis 客户端 所有 key 会加上 keyPrefix 前缀
+func Init(cfg *settings.RedisConfig, keyPrefix string) (err error) {
+	c := redis.NewClient(&redis.Options{
+		Addr:         net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port)),
+		Password:     cfg.Password,
+		DB:           cfg.DB,
+		PoolSize:     cfg.PoolSize,
+		MinIdleConns: cfg.MinIdleConns,
+	})
+	c.AddHook(tracingHook{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), pingTimeout)
+	defer cancel()
+	if err = c.Ping(ctx).Err(); err != nil {
+		_ = c.Close()
+		return
+	}
+	client = c
+	prefix = keyPrefix
+	return
+}
+
+// Client 获取原始客户端 用于辅助方法未覆盖的命令 注意自行调用 Key 拼接前缀
+func Client() *redis.Client {
+	return client
+}
+
+// Key 拼接 key 形如 prefix:part1:part2
+func Key(parts ...string) string {
+	key := strings.Join(parts, ":")
+	if prefix == "" {
+		return key
+	}
+	return prefix + ":" + key
+}
+
+// Ping 检查连接是否可用
+func Ping(ctx context.Context) error {
+	return client.Ping(ctx).Err()
+}
+
+// Close 关闭客户端
+func Close() error {
+	if client == nil {
+		return nil
+	}
+	return client.Close()
+}
+
+// Get 获取字符串 key 不存在时返回 ErrNil
+func Get(ctx context.Context, key string) (string, error) {
+	return client.Get(ctx, Key(key)).Result()
+}
+
+// GetInt64 获取整型值 key 不存在时返回 ErrNil
+func GetInt64(ctx context.Context, key string) (int64, error) {
+	return client.Get(ctx, Key(key)).Int64()
+}
+
+// Set 设置值 ttl 为 0 表示永不过期
+func Set(ctx context.Context, key string, value any, ttl time.Duration) error {
+	return client.Set(ctx, Key(key), value, ttl).Err()
+}
+
+// SetNX key 不存在时才设置 返回是否设置成功
+func SetNX(ctx context.Context, key string, value any, ttl time.Duration) (bool, error) {
+	return client.SetNX(ctx, Key(key), value, ttl).Result()
+}
+
+// Del 删除 key 返回实际删除的数量
+func Del(ctx context.Context, keys ...string) (int64, error) {
+	ks := make([]string, len(keys))
+	for i, k := range keys {
+		ks[i] = Key(k)
+	}
+	return client.Del(ctx, ks...).Result()
+}
+
+// Incr 自增 1
+func Incr(ctx context.Context, key string) (int64, error) {
+	return client.Incr(ctx, Key(key)).Result()
+}
+
+// incrWithTTLScript 自增后 key 尚无过期时间时设置 EXPIRE NX 需要 Redis 7.0 以脚本兼容 6.x
+var incrWithTTLScript = redis.NewScript(`
+local n = redis.call("INCR", KEYS[1])
+if n == 1 or redis.call("PTTL", KEYS[1]) == -1 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+return n
+`)
+
+// IncrWithTTL 自增 1 且在 key 首次创建时设置过期时间 常用于计数限流
+func IncrWithTTL(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	return incrWithTTLScript.Run(ctx, client, []string{Key(key)}, ttl.Milliseconds()).Int64()
+}
+
+// GetJSON 获取 JSON 序列化的值
+func GetJSON[T any](ctx context.Context, key string) (v T, err error) {
+	b, err := client.Get(ctx, Key(key)).Bytes()
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(b, &v)
+	return
+}
+
+// SetJSON 以 JSON 序列化后设置值
+func SetJSON(ctx context.Context, key string, value any, ttl time.Duration) error {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return client.Set(ctx, Key(key), b, ttl).Err()
+}
+
+// IsNil 判断是否为 key 不存在的错误
+func IsNil(err error) bool {
+	return errors.Is(err, ErrNil)
+}
@@ -0,0 +1,149 @@
+/**
+ * @Author: LiuShuXin
+ * @Description:
+ * @File:  redis_test
+ * Software: Goland
+ * @Date: 2026/10/18 11:05
+ */
+
+package redis
+
+import (
+	"app-server/settings"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func setup(t *testing.T) *miniredis.Miniredis {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	cfg := &settings.RedisConfig{
+		Host:     mr.Host(),
+		Port:     mr.Server().Addr().Port,
+		PoolSize: 10,
+	}
+	if err := Init(cfg, "report"); err != nil {
+		t.Fatalf("Init() err: %v", err)
+	}
+	t.Cleanup(func() { _ = Close() })
+	return mr
+}
+
+func TestKey(t *testing.T) {
+	setup(t)
+	if got := Key("user", "1"); got != "report:user:1" {
+		t.Errorf("Key() = %s, want report:user:1", got)
+	}
+}
+
+func TestSetGetDel(t *testing.T) {
+	mr := setup(t)
+	ctx := context.Background()
+
+	if err := Set(ctx, "name", "app", time.Minute); err != nil {
+		t.Fatalf("Set() err: %v", err)
+	}
+	if !mr.Exists("report:name") {
+		t.Fatal("key should be stored with prefix")
+	}
+	if got, err := Get(ctx, "name"); err != nil || got != "app" {
+		t.Errorf("Get() = %s, %v", got, err)
+	}
+
+	mr.FastForward(2 * time.Minute)
+	if _, err := Get(ctx, "name"); !IsNil(err) {
+		t.Errorf("Get() after ttl err = %v, want ErrNil", err)
+	}
+
+	_ = Set(ctx, "a", 1, 0)
+	if n, err := Del(ctx, "a", "b"); err != nil || n != 1 {
+		t.Errorf("Del() = %d, %v", n, err)
+	}
+}
+
+func TestIncrWithTTL(t *testing.T) {
+	mr := setup(t)
+	ctx := context.Background()
+
+	for i := int64(1); i <= 3; i++ {
+		n, err := IncrWithTTL(ctx, "counter", time.Minute)
+		if err != nil || n != i {
+			t.Fatalf("IncrWithTTL() = %d, %v, want %d", n, err, i)
+		}
+	}
+	if ttl := mr.TTL("report:counter"); ttl != time.Minute {
+		t.Errorf("ttl = %v, want %v", ttl, time.Minute)
+	}
+
+	// 已存在但没有过期时间的 key 补上过期时间
+	mr.Set("report:legacy", "5")
+	if n, err := IncrWithTTL(ctx, "legacy", time.Minute); err != nil || n != 6 {
+		t.Fatalf("IncrWithTTL() = %d, %v, want 6", n, err)
+	}
+	if ttl := mr.TTL("report:legacy"); ttl != time.Minute {
+		t.Errorf("legacy ttl = %v, want %v", ttl, time.Minute)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	setup(t)
+	ctx := context.Background()
+
+	type user struct {
+		ID   int64  `json:"id"`
+		Name string `json:"name"`
+	}
+	if err := SetJSON(ctx, "user:1", user{ID: 1, Name: "tom"}, time.Minute); err != nil {
+		t.Fatalf("SetJSON() err: %v", err)
+	}
+	u, err := GetJSON[user](ctx, "user:1")
+	if err != nil || u.Name != "tom" {
+		t.Errorf("GetJSON() = %+v, %v", u, err)
+	}
+}
+
+func TestTracingHook(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr)))
+	defer otel.SetTracerProvider(prev)
+
+	setup(t)
+	ctx := context.Background()
+	if _, err := Get(ctx, Key("missing")); !IsNil(err) {
+		t.Fatalf("Get() err = %v", err)
+	}
+	if _, err := IncrWithTTL(ctx, Key("counter"), time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Client().Pipelined(ctx, func(p redis.Pipeliner) error {
+		p.Incr(ctx, Key("a"))
+		p.Incr(ctx, Key("b"))
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	names := map[string]codes.Code{}
+	for _, s := range sr.Ended() {
+		names[s.Name()] = s.Status().Code
+	}
+	// key 不存在不视为错误
+	if code, ok := names["redis.get"]; !ok || code == codes.Error {
+		t.Errorf("redis.get span = %v, %v", code, ok)
+	}
+	if code, ok := names["redis.evalsha"]; !ok || code == codes.Error {
+		t.Errorf("redis.evalsha span = %v, %v", code, ok)
+	}
+	if _, ok := names["redis.pipeline"]; !ok {
+		t.Errorf("spans = %v, want redis.pipeline", names)
+	}
+}
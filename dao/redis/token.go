@@ -0,0 +1,75 @@
+/**
+ * @Author: LiuShuXin
+ * @Description: token 相关的共享存储
+ * @File:  token
+ * Software: Goland
+ * @Date: 2026/10/18 15:05
+ */
+
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// rotateScript 当前值等于旧 jti 时原子地替换为新 jti
+var rotateScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	redis.call("SET", KEYS[1], ARGV[2], "PX", ARGV[3])
+	return 1
+end
+return 0
+`)
+
+// RefreshStore refresh token 轮换链存储 实现 jwt.RefreshStore
+type RefreshStore struct{}
+
+func NewRefreshStore() *RefreshStore {
+	return &RefreshStore{}
+}
+
+func (RefreshStore) key(family string) string {
+	return Key("refresh", family)
+}
+
+func (s RefreshStore) Save(ctx context.Context, family, jti string, ttl time.Duration) error {
+	return client.Set(ctx, s.key(family), jti, ttl).Err()
+}
+
+func (s RefreshStore) Rotate(ctx context.Context, family, oldJTI, newJTI string, ttl time.Duration) (bool, error) {
+	n, err := rotateScript.Run(ctx, client, []string{s.key(family)}, oldJTI, newJTI, ttl.Milliseconds()).Int()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
+
+func (s RefreshStore) Revoke(ctx context.Context, family string) error {
+	return client.Del(ctx, s.key(family)).Err()
+}
+
+// RevocationStore token 吊销名单存储 实现 jwt.RevocationStore
+type RevocationStore struct{}
+
+func NewRevocationStore() *RevocationStore {
+	return &RevocationStore{}
+}
+
+func (RevocationStore) key(jti string) string {
+	return Key("revoked", jti)
+}
+
+func (s RevocationStore) Revoke(ctx context.Context, jti string, ttl time.Duration) error {
+	return client.Set(ctx, s.key(jti), 1, ttl).Err()
+}
+
+func (s RevocationStore) IsRevoked(ctx context.Context, jti string) (bool, error) {
+	n, err := client.Exists(ctx, s.key(jti)).Result()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
@@ -0,0 +1,52 @@
+/**
+ * @Author: LiuShuXin
+ * @Description:
+ * @File:  token_test
+ * Software: Goland
+ * @Date: 2026/10/18 15:30
+ */
+
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRefreshStore(t *testing.T) {
+	setup(t)
+	ctx := context.Background()
+	s := NewRefreshStore()
+
+	if err := s.Save(ctx, "fam", "jti-1", time.Hour); err != nil {
+		t.Fatalf("Save() err: %v", err)
+	}
+	if ok, err := s.Rotate(ctx, "fam", "jti-1", "jti-2", time.Hour); err != nil || !ok {
+		t.Fatalf("Rotate() = %v, %v", ok, err)
+	}
+	if ok, _ := s.Rotate(ctx, "fam", "jti-1", "jti-3", time.Hour); ok {
+		t.Error("Rotate() with stale jti should fail")
+	}
+	_ = s.Revoke(ctx, "fam")
+	if ok, _ := s.Rotate(ctx, "fam", "jti-2", "jti-3", time.Hour); ok {
+		t.Error("Rotate() after revoke should fail")
+	}
+}
+
+func TestRevocationStore(t *testing.T) {
+	mr := setup(t)
+	ctx := context.Background()
+	s := NewRevocationStore()
+
+	if err := s.Revoke(ctx, "jti-1", time.Minute); err != nil {
+		t.Fatalf("Revoke() err: %v", err)
+	}
+	if revoked, err := s.IsRevoked(ctx, "jti-1"); err != nil || !revoked {
+		t.Errorf("IsRevoked() = %v, %v", revoked, err)
+	}
+	mr.FastForward(2 * time.Minute)
+	if revoked, _ := s.IsRevoked(ctx, "jti-1"); revoked {
+		t.Error("record should expire with the token")
+	}
+}
@@ -0,0 +1,55 @@
+/**
+ * @Author: LiuShuXin
+ * @Description: 为 Redis 命令创建 span
+ * @File:  tracing
+ * Software: Goland
+ * @Date: 2026/10/19 20:40
+ */
+
+package redis
+
+import (
+	"app-server/pkg/tracing"
+	"context"
+	"errors"
+
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+// tracingHook 每条命令或每个 pipeline 对应一个 span key 不写入 span 避免泄露业务数据
+type tracingHook struct{}
+
+func (tracingHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (tracingHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		ctx, span := tracing.Start(ctx, "redis."+cmd.Name(), semconv.DBSystemRedis, semconv.DBOperationName(cmd.Name()))
+		err := next(ctx, cmd)
+		tracing.End(span, ignoreNil(err))
+		return err
+	}
+}
+
+func (tracingHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		ctx, span := tracing.Start(ctx, "redis.pipeline", semconv.DBSystemRedis,
+			semconv.DBOperationName("pipeline"), attribute.Int("db.redis.pipeline_length", len(cmds)))
+		err := next(ctx, cmds)
+		tracing.End(span, ignoreNil(err))
+		return err
+	}
+}
+
+// ignoreNil key 不存在不视为错误 脚本未缓存时 EVALSHA 返回的 NOSCRIPT 会自动改用 EVAL 同样忽略
+func ignoreNil(err error) error {
+	if errors.Is(err, redis.Nil) || redis.HasErrorPrefix(err, "NOSCRIPT") {
+		return nil
+	}
+	return err
+}
+
+var _ redis.Hook = tracingHook{}
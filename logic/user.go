@@ -0,0 +1,94 @@
+/**
+ * @Author: LiuShuXin
+ * @Description: 用户注册、登录业务逻辑
+ * @File:  user
+ * Software: Goland
+ * @Date: 2026/10/18 16:50
+ */
+
+package logic
+
+import (
+	"app-server/dao"
+	"app-server/dao/memory"
+	"app-server/models"
+	"app-server/pkg/errcode"
+	"app-server/pkg/jwt"
+	"app-server/pkg/snowflake"
+	"context"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	ErrInvalidPassword = errcode.New(errcode.CodeInvalidPassword)
+	ErrUserDisabled    = errcode.New(errcode.CodeUserDisabled)
+)
+
+var userRepo dao.UserRepository = memory.NewUserRepo()
+
+// SetUserRepository 替换用户存储 需在处理请求前调用
+func SetUserRepository(r dao.UserRepository) {
+	userRepo = r
+}
+
+// SignUp 注册
+func SignUp(ctx context.Context, p *models.ParamSignUp) (*models.User, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(p.Password), bcrypt.DefaultCost)
+	if errors.Is(err, bcrypt.ErrPasswordTooLong) {
+		return nil, errcode.New(errcode.CodeInvalidParam).WithCause(err).WithField("password", err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	user := &models.User{
+		UserID:   snowflake.GenID(),
+		Username: p.Username,
+		Password: string(hash),
+		Role:     models.RoleUser,
+	}
+	if err = userRepo.InsertUser(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// Login 登录 校验密码后签发 token
+func Login(ctx context.Context, p *models.ParamLogin) (*models.User, *jwt.TokenPair, error) {
+	user, err := userRepo.GetUserByUsername(ctx, p.Username)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(p.Password)); err != nil {
+		return nil, nil, ErrInvalidPassword
+	}
+	if user.Disabled {
+		return nil, nil, ErrUserDisabled
+	}
+	pair, err := jwt.GenTokenPair(ctx, user.UserID, user.Username, user.Role)
+	if err != nil {
+		return nil, nil, err
+	}
+	return user, pair, nil
+}
+
+// Refresh 刷新 token 按用户 ID 重新加载角色 用户已被禁用或删除时拒绝刷新
+func Refresh(ctx context.Context, refreshToken string) (*jwt.TokenPair, error) {
+	return jwt.RefreshToken(ctx, refreshToken, loadUser)
+}
+
+func loadUser(ctx context.Context, userID int64) (string, []string, error) {
+	user, err := userRepo.GetUserByID(ctx, userID)
+	if errors.Is(err, dao.ErrUserNotExist) {
+		return "", nil, fmt.Errorf("%w: user %d not exist", jwt.ErrInvalidToken, userID)
+	}
+	if err != nil {
+		return "", nil, err
+	}
+	if user.Disabled {
+		return "", nil, ErrUserDisabled
+	}
+	return user.Username, []string{user.Role}, nil
+}
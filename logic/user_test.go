@@ -0,0 +1,125 @@
+/**
+ * @Author: LiuShuXin
+ * @Description:
+ * @File:  user_test
+ * Software: Goland
+ * @Date: 2026/10/18 17:10
+ */
+
+package logic
+
+import (
+	"app-server/dao"
+	"app-server/dao/memory"
+	"app-server/models"
+	"app-server/pkg/errcode"
+	"app-server/pkg/jwt"
+	"app-server/pkg/snowflake"
+	"app-server/settings"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMain(m *testing.M) {
+	if err := snowflake.Init("2024-11-07", 1); err != nil {
+		panic(err)
+	}
+	if err := jwt.Init(&settings.Auth{JwtSecret: "test-secret"}, "test"); err != nil {
+		panic(err)
+	}
+	m.Run()
+}
+
+func TestSignUpAndLogin(t *testing.T) {
+	SetUserRepository(memory.NewUserRepo())
+	ctx := context.Background()
+
+	user, err := SignUp(ctx, &models.ParamSignUp{Username: "tom", Password: "123456", RePassword: "123456"})
+	if err != nil {
+		t.Fatalf("SignUp() err: %v", err)
+	}
+	if user.UserID == 0 || user.Password == "123456" {
+		t.Errorf("user = %+v, want generated id and hashed password", user)
+	}
+	if _, err = SignUp(ctx, &models.ParamSignUp{Username: "tom", Password: "654321"}); !errors.Is(err, dao.ErrUserExist) {
+		t.Errorf("SignUp() duplicate err = %v, want %v", err, dao.ErrUserExist)
+	}
+
+	got, pair, err := Login(ctx, &models.ParamLogin{Username: "tom", Password: "123456"})
+	if err != nil {
+		t.Fatalf("Login() err: %v", err)
+	}
+	mc, err := jwt.ParseToken(pair.AccessToken)
+	if err != nil || mc.UserID != got.UserID {
+		t.Errorf("ParseToken() = %+v, %v", mc, err)
+	}
+
+	if _, _, err = Login(ctx, &models.ParamLogin{Username: "tom", Password: "wrong"}); !errors.Is(err, ErrInvalidPassword) {
+		t.Errorf("Login() err = %v, want %v", err, ErrInvalidPassword)
+	}
+	if _, _, err = Login(ctx, &models.ParamLogin{Username: "jerry", Password: "123456"}); !errors.Is(err, dao.ErrUserNotExist) {
+		t.Errorf("Login() err = %v, want %v", err, dao.ErrUserNotExist)
+	}
+}
+
+// changedRepo 模拟登录后用户被降级或禁用
+type changedRepo struct {
+	*memory.UserRepo
+	role     string
+	disabled bool
+}
+
+func (r *changedRepo) GetUserByID(ctx context.Context, userID int64) (*models.User, error) {
+	u, err := r.UserRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	u.Role, u.Disabled = r.role, r.disabled
+	return u, nil
+}
+
+func TestRefreshReloadsUser(t *testing.T) {
+	repo := &changedRepo{UserRepo: memory.NewUserRepo(), role: models.RoleAdmin}
+	SetUserRepository(repo)
+	ctx := context.Background()
+
+	if _, err := SignUp(ctx, &models.ParamSignUp{Username: "tom", Password: "123456", RePassword: "123456"}); err != nil {
+		t.Fatal(err)
+	}
+	_, pair, err := Login(ctx, &models.ParamLogin{Username: "tom", Password: "123456"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 降级后刷新得到的是新角色
+	repo.role = models.RoleUser
+	next, err := Refresh(ctx, pair.RefreshToken)
+	if err != nil {
+		t.Fatalf("Refresh() err: %v", err)
+	}
+	if mc, _ := jwt.ParseToken(next.AccessToken); mc == nil || len(mc.Roles) != 1 || mc.Roles[0] != models.RoleUser {
+		t.Errorf("roles after demotion = %+v", mc)
+	}
+
+	repo.disabled = true
+	if _, err = Refresh(ctx, next.RefreshToken); !errors.Is(err, ErrUserDisabled) {
+		t.Errorf("Refresh() disabled err = %v, want %v", err, ErrUserDisabled)
+	}
+
+	// 用户已删除
+	SetUserRepository(memory.NewUserRepo())
+	if _, err = Refresh(ctx, next.RefreshToken); !errors.Is(err, jwt.ErrInvalidToken) {
+		t.Errorf("Refresh() deleted err = %v, want %v", err, jwt.ErrInvalidToken)
+	}
+}
+
+func TestSignUpPasswordTooLong(t *testing.T) {
+	SetUserRepository(memory.NewUserRepo())
+	pwd := strings.Repeat("密", 30) // 90 字节
+	_, err := SignUp(context.Background(), &models.ParamSignUp{Username: "tom", Password: pwd, RePassword: pwd})
+	if e, ok := errcode.From(err); !ok || e.Code != errcode.CodeInvalidParam {
+		t.Errorf("SignUp() err = %v, want CodeInvalidParam", err)
+	}
+}
@@ -1,19 +1,46 @@
 package main
 
 import (
+	"app-server/controller"
+	"app-server/dao/mongoDB"
+	"app-server/dao/mysql"
+	"app-server/dao/redis"
+	"app-server/logic"
+	"app-server/middlewares"
+	"app-server/pkg/apikey"
+	"app-server/pkg/errcode"
+	"app-server/pkg/health"
+	"app-server/pkg/jwt"
 	"app-server/pkg/logger"
+	"app-server/pkg/metrics"
+	"app-server/pkg/pagination"
+	"app-server/pkg/rbac"
+	"app-server/pkg/shutdown"
 	"app-server/pkg/snowflake"
+	"app-server/pkg/tracing"
 	"app-server/router"
 	"app-server/settings"
+	"context"
+	"errors"
 	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"go.uber.org/zap"
 )
 
+const defaultShutdownTimeout = 10 * time.Second
+
 func main() {
 
 	// 加载配置
 	if err := settings.Init(); err != nil {
 		fmt.Printf("load config failed, err:%v\n", err)
-		return
+		os.Exit(1)
 	}
 
 	appLog := logger.NewDayLogger("app", 3)
@@ -22,27 +49,237 @@ func main() {
 	// 初始化日志
 	if err := logger.Init(settings.GetConf().LogConfig, settings.GetConf().Mode); err != nil {
 		fmt.Printf("init logger failed, err:%v\n", err)
-		return
+		os.Exit(1)
+	}
+
+	// 初始化链路追踪
+	if err := tracing.Init(settings.GetConf().Tracing, settings.GetConf().Name, settings.GetConf().Version); err != nil {
+		fmt.Printf("init tracing failed, err:%v\n", err)
+		os.Exit(1)
+	}
+
+	// 初始化 Prometheus 指标
+	if err := metrics.Init(settings.GetConf().Metrics, settings.GetConf().Name); err != nil {
+		fmt.Printf("init metrics failed, err:%v\n", err)
+		os.Exit(1)
+	}
+
+	// 加载状态码提示语言包
+	i18nDir := settings.GetConf().I18nDir
+	if i18nDir == "" {
+		i18nDir = "./conf/i18n"
+	}
+	if err := errcode.LoadCatalogs(i18nDir); err != nil {
+		fmt.Printf("load i18n catalogs failed, err:%v\n", err)
+		os.Exit(1)
+	}
+
+	// 注册参数校验的翻译及自定义规则
+	if err := controller.InitValidator(); err != nil {
+		fmt.Printf("init validator failed, err:%v\n", err)
+		os.Exit(1)
 	}
 
 	// 初始化数据库连接 Mysql | MongoDB | Redis
+	if cfg := settings.GetConf().MySQLConfig; cfg != nil && cfg.Enable {
+		if err := mysql.Init(cfg); err != nil {
+			fmt.Printf("init mysql failed, err:%v\n", err)
+			os.Exit(1)
+		}
+		logic.SetUserRepository(mysql.NewUserRepo())
+	} else {
+		zap.L().Warn("mysql is disabled, users are kept in memory")
+	}
+	if cfg := settings.GetConf().RedisConfig; cfg != nil && cfg.Enable {
+		if err := redis.Init(cfg, settings.GetConf().Name); err != nil {
+			fmt.Printf("init redis failed, err:%v\n", err)
+			os.Exit(1)
+		}
+		// 多实例部署时 refresh token 轮换状态及吊销名单需存放在 Redis 中
+		jwt.SetRefreshStore(redis.NewRefreshStore())
+		jwt.SetRevocationStore(redis.NewRevocationStore())
+	}
+	if cfg := settings.GetConf().MongoConfig; cfg != nil && cfg.Enable {
+		if err := mongoDB.Init(cfg); err != nil {
+			fmt.Printf("init mongodb failed, err:%v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	// 注册就绪检查
+	if cfg := settings.GetConf().MySQLConfig; cfg != nil && cfg.Enable {
+		health.Register("mysql", health.DefaultTimeout, mysql.Ping)
+	}
+	if cfg := settings.GetConf().RedisConfig; cfg != nil && cfg.Enable {
+		health.Register("redis", health.DefaultTimeout, redis.Ping)
+	}
+	if cfg := settings.GetConf().MongoConfig; cfg != nil && cfg.Enable {
+		health.Register("mongodb", health.DefaultTimeout, mongoDB.Ping)
+	}
+
+	// 注册连接池指标
+	if metrics.Enabled() {
+		var pools []prometheus.Collector
+		if cfg := settings.GetConf().MySQLConfig; cfg != nil && cfg.Enable {
+			pools = append(pools, collectors.NewDBStatsCollector(mysql.DB().DB, cfg.DBName))
+		}
+		if cfg := settings.GetConf().RedisConfig; cfg != nil && cfg.Enable {
+			pools = append(pools, redis.NewPoolCollector(metrics.Namespace()))
+		}
+		if cfg := settings.GetConf().MongoConfig; cfg != nil && cfg.Enable {
+			pools = append(pools, mongoDB.NewPoolCollector(metrics.Namespace()))
+		}
+		for _, c := range pools {
+			if err := metrics.Register(c); err != nil {
+				fmt.Printf("register pool metrics failed, err:%v\n", err)
+				os.Exit(1)
+			}
+		}
+	}
 
 	// 初始化雪花算法
 	if err := snowflake.Init(settings.GetConf().StartTime, settings.GetConf().MachineID); err != nil {
 		fmt.Printf("init snowflake failed, err:%v\n", err)
-		return
+		os.Exit(1)
 	}
 
-	// 业务模块初始化 如自定义的定时任务等
+	// 初始化 JWT 签名配置
+	if err := jwt.Init(settings.GetConf().Auth, settings.GetConf().Mode); err != nil {
+		fmt.Printf("init jwt failed, err:%v\n", err)
+		os.Exit(1)
+	}
 
-	// 注册信号量 实现服务优雅启停 todo
+	// 设置获取 token 的位置
+	if err := middlewares.SetTokenLookup(settings.GetConf().TokenLookup); err != nil {
+		fmt.Printf("init token lookup failed, err:%v\n", err)
+		os.Exit(1)
+	}
+	if cfg := settings.GetConf().TokenCookie; cfg != nil {
+		middlewares.SetTrustedOrigins(cfg.TrustedOrigins)
+	}
+
+	// 加载角色权限策略
+	if err := rbac.Init(settings.GetConf().PolicyFile); err != nil {
+		fmt.Printf("init rbac policy failed, err:%v\n", err)
+		os.Exit(1)
+	}
+
+	// 加载服务间调用的 API Key
+	if settings.GetConf().APIKeyStore == "redis" {
+		if cfg := settings.GetConf().RedisConfig; cfg == nil || !cfg.Enable {
+			fmt.Println("init api keys failed, err:redis is disabled")
+			os.Exit(1)
+		}
+		apikey.SetStore(redis.NewAPIKeyStore())
+	} else if err := apikey.Init(settings.GetConf().APIKeys); err != nil {
+		fmt.Printf("init api keys failed, err:%v\n", err)
+		os.Exit(1)
+	}
+
+	// 列表接口分页参数
+	pagination.Init(settings.GetConf().Pagination)
+
+	// 业务模块初始化 如自定义的定时任务等
 
 	// 注册路由
-	r := router.SetupRouter(settings.GetConf().Mode)
-	// 启动服务
-	err := r.Run(fmt.Sprintf("%s:%d", settings.GetConf().Addr, settings.GetConf().Port))
+	controller.SetStrictHTTPStatus(settings.GetConf().StrictHTTPStatus)
+	admin, err := router.NewAdminOptions(settings.GetConf().Admin, settings.GetConf().Mode)
+	if err != nil {
+		fmt.Printf("init admin routes failed, err:%v\n", err)
+		os.Exit(1)
+	}
+	r := router.SetupRouter(settings.GetConf().Mode, admin)
+	srv := &http.Server{
+		Addr:    fmt.Sprintf("%s:%d", settings.GetConf().Addr, settings.GetConf().Port),
+		Handler: r,
+	}
+	// 先同步监听端口 端口被占用等启动错误直接退出
+	ln, err := net.Listen("tcp", srv.Addr)
 	if err != nil {
-		fmt.Printf("run server failed, err:%v\n", err)
-		return
+		fmt.Printf("listen failed, err:%v\n", err)
+		os.Exit(1)
+	}
+	// pprof、swagger 挂载到独立的管理端口 仅在内网开放
+	var adminSrv *http.Server
+	var adminLn net.Listener
+	if admin.Addr != "" && admin.Enabled() {
+		adminSrv = &http.Server{Addr: admin.Addr, Handler: router.SetupAdminRouter(admin)}
+		if adminLn, err = net.Listen("tcp", adminSrv.Addr); err != nil {
+			fmt.Printf("listen admin failed, err:%v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	// 注册关闭钩子 顺序：HTTP 服务 -> 管理端口 -> 数据库连接池 -> 链路追踪 -> 日志
+	// 雪花发号器不持有外部资源 不注册钩子：HTTP 服务 Shutdown 超时返回后仍可能有处理中的请求需要生成 ID
+	shutdown.Register("http", srv.Shutdown)
+	if adminSrv != nil {
+		shutdown.Register("admin", adminSrv.Shutdown)
+	}
+	shutdown.Register("mysql", func(context.Context) error {
+		return mysql.Close()
+	})
+	shutdown.Register("redis", func(context.Context) error {
+		return redis.Close()
+	})
+	shutdown.Register("mongodb", mongoDB.Close)
+	shutdown.Register("tracing", tracing.Close)
+	shutdown.Register("logger", func(context.Context) error {
+		_ = logger.Sync() // 输出到终端时 Sync 可能返回 invalid argument，忽略即可
+		return appLog.Close()
+	})
+
+	// 注册信号量 实现服务优雅启停
+	quit := shutdown.Notify()
+
+	// 启动服务
+	serveErr := make(chan error, 1)
+	go func() {
+		if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serveErr <- err
+		}
+	}()
+	zap.L().Info("server started", zap.String("addr", srv.Addr))
+	if adminSrv != nil {
+		go func() {
+			if err := adminSrv.Serve(adminLn); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				select {
+				case serveErr <- err:
+				default:
+				}
+			}
+		}()
+		zap.L().Info("admin server started", zap.String("addr", adminSrv.Addr))
+	}
+
+	exitCode := 0
+	select {
+	case s := <-quit:
+		zap.L().Info("shutdown server ...", zap.String("signal", s.String()))
+	case err := <-serveErr:
+		zap.L().Error("run server failed", zap.Error(err))
+		exitCode = 1
+	}
+
+	// 就绪探针立即失败 等待负载均衡探测到后摘除实例 再关闭监听
+	health.SetShuttingDown()
+	if drain := time.Duration(settings.GetConf().ShutdownDrain) * time.Second; drain > 0 && exitCode == 0 {
+		zap.L().Info("draining before shutdown", zap.Duration("delay", drain))
+		select {
+		case <-time.After(drain):
+		case <-quit:
+		}
+	}
+
+	timeout := time.Duration(settings.GetConf().ShutdownTimeout) * time.Second
+	if timeout <= 0 {
+		timeout = defaultShutdownTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	if err := shutdown.Run(ctx); err != nil {
+		fmt.Printf("shutdown failed, err:%v\n", err)
+		exitCode = 1
 	}
+	cancel()
+	os.Exit(exitCode)
 }
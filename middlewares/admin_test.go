@@ -0,0 +1,67 @@
+/**
+ * @Author: LiuShuXin
+ * @Description:
+ * @File:  admin_test
+ * Software: Goland
+ * @Date: 2026/10/20 11:50
+ */
+
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestAdminGuard(t *testing.T) {
+	cases := []struct {
+		name     string
+		username string
+		allowIPs []string
+		remote   string
+		auth     []string
+		status   int
+	}{
+		{"default loopback", "", nil, "127.0.0.1:1234", nil, http.StatusOK},
+		{"default deny remote", "", nil, "10.0.0.8:1234", nil, http.StatusForbidden},
+		{"cidr allow", "", []string{"10.0.0.0/8"}, "10.1.2.3:1234", nil, http.StatusOK},
+		{"basic auth ok", "admin", nil, "10.0.0.8:1234", []string{"admin", "secret"}, http.StatusOK},
+		{"basic auth wrong", "admin", nil, "10.0.0.8:1234", []string{"admin", "wrong"}, http.StatusUnauthorized},
+		{"basic auth missing", "admin", nil, "10.0.0.8:1234", nil, http.StatusUnauthorized},
+		{"both ip denied", "admin", []string{"127.0.0.1"}, "10.0.0.8:1234", []string{"admin", "secret"}, http.StatusForbidden},
+		{"both ok", "admin", []string{"127.0.0.1"}, "127.0.0.1:1234", []string{"admin", "secret"}, http.StatusOK},
+	}
+	for _, tc := range cases {
+		guard, err := AdminGuard(tc.username, "secret", tc.allowIPs)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		r := gin.New()
+		r.GET("/debug", guard, func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})
+		req := httptest.NewRequest(http.MethodGet, "/debug", nil)
+		req.RemoteAddr = tc.remote
+		if tc.auth != nil {
+			req.SetBasicAuth(tc.auth[0], tc.auth[1])
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if w.Code != tc.status {
+			t.Errorf("%s: status = %d, want %d", tc.name, w.Code, tc.status)
+		}
+		if w.Code == http.StatusUnauthorized && w.Header().Get("WWW-Authenticate") == "" {
+			t.Errorf("%s: missing WWW-Authenticate", tc.name)
+		}
+	}
+
+	if _, err := AdminGuard("", "", []string{"not-an-ip"}); err == nil {
+		t.Error("invalid allow ip should fail")
+	}
+	if _, err := AdminGuard("admin", "", nil); err == nil {
+		t.Error("username without password should fail")
+	}
+}
@@ -0,0 +1,43 @@
+/**
+ * @Author: LiuShuXin
+ * @Description:
+ * @File:  apikey
+ * Software: Goland
+ * @Date: 2026/10/18 19:50
+ */
+
+package middlewares
+
+import (
+	"app-server/controller"
+	"app-server/pkg/apikey"
+	"errors"
+	"github.com/gin-gonic/gin"
+)
+
+const APIKeyHeader = "X-API-Key"
+
+// APIKeyAuthMiddleware 基于 API Key 的认证中间件 用于服务间调用
+func APIKeyAuthMiddleware() gin.HandlerFunc {
+	return Authenticate(APIKeyAuthenticator)
+}
+
+// APIKeyAuthenticator 基于 API Key 的认证方式 从请求头 X-API-Key 获取
+// 调用方的权限由 Key 的 scopes 决定，用户名形如 apikey:<name>
+func APIKeyAuthenticator(c *gin.Context) (controller.UserInfo, error) {
+	plain := c.Request.Header.Get(APIKeyHeader)
+	if plain == "" {
+		return controller.UserInfo{}, errNoCredentials
+	}
+	key, err := apikey.Verify(c.Request.Context(), plain)
+	if errors.Is(err, apikey.ErrInvalidKey) || errors.Is(err, apikey.ErrExpiredKey) {
+		return controller.UserInfo{}, &authError{code: controller.CodeInvalidAPIKey, err: err, scheme: schemeAPIKey}
+	}
+	if err != nil {
+		return controller.UserInfo{}, err
+	}
+	return controller.UserInfo{
+		Username: "apikey:" + key.Name,
+		Scopes:   key.Scopes,
+	}, nil
+}
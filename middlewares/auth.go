@@ -0,0 +1,122 @@
+/**
+ * @Author: LiuShuXin
+ * @Description: 认证中间件的公共部分 支持组合多种认证方式
+ * @File:  auth
+ * Software: Goland
+ * @Date: 2026/10/18 19:40
+ */
+
+package middlewares
+
+import (
+	"app-server/controller"
+	"app-server/pkg/logger"
+	"app-server/settings"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"net/http"
+	"strings"
+)
+
+// WWW-Authenticate 中的认证方案
+const (
+	schemeBearer = "Bearer"
+	schemeAPIKey = "ApiKey"
+)
+
+// errNoCredentials 请求未携带当前认证方式的凭证 交由下一种认证方式处理
+var errNoCredentials = errors.New("no credentials")
+
+// authError 凭证无效 code 为返回给客户端的业务状态码 scheme 为 WWW-Authenticate 中的认证方案
+type authError struct {
+	code   controller.ResCode
+	err    error
+	scheme string
+}
+
+func (e *authError) Error() string { return e.err.Error() }
+
+func (e *authError) Unwrap() error { return e.err }
+
+// Authenticator 一种认证方式
+// 请求未携带该方式的凭证时返回 errNoCredentials，凭证无效时返回 *authError
+type Authenticator func(c *gin.Context) (controller.UserInfo, error)
+
+// Authenticate 按顺序尝试各认证方式 以第一个携带了凭证的方式为准
+// 如 Authenticate(JWTAuthenticator, APIKeyAuthenticator) 表示 JWT 与 API Key 均可
+func Authenticate(authenticators ...Authenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for _, auth := range authenticators {
+			u, err := auth(c)
+			if errors.Is(err, errNoCredentials) {
+				continue
+			}
+			if err != nil {
+				abortWithAuthError(c, err)
+				return
+			}
+			controller.SetCurrentUser(c, u)
+			c.Next()
+			return
+		}
+		// 未携带凭证 按 RFC 6750 只返回认证方案与 realm
+		c.Header("WWW-Authenticate", challenge(schemeBearer, "", ""))
+		controller.ResponseErrorWithStatus(c, http.StatusUnauthorized, controller.CodeNeedLogin)
+		c.Abort()
+	}
+}
+
+// abortWithAuthError 凭证无效返回 401，cookie 认证的跨站请求返回 403，其余错误（如吊销名单不可用）视为服务端错误
+func abortWithAuthError(c *gin.Context, err error) {
+	var ae *authError
+	if errors.As(err, &ae) {
+		c.Header("WWW-Authenticate", challenge(ae.scheme, "invalid_token", ae.Error()))
+		controller.ResponseErrorWithStatus(c, http.StatusUnauthorized, ae.code)
+	} else if errors.Is(err, errCrossSite) {
+		controller.ResponseErrorWithStatus(c, http.StatusForbidden, controller.CodeForbidden)
+	} else {
+		logger.WithContext(c.Request.Context()).Error("authenticate failed", zap.Error(err))
+		controller.ResponseErrorWithStatus(c, http.StatusInternalServerError, controller.CodeServerBusy)
+	}
+	c.Abort()
+}
+
+// abortForbidden 已认证但权限不足 返回 403
+func abortForbidden(c *gin.Context) {
+	c.Header("WWW-Authenticate", challenge(schemeBearer, "insufficient_scope", "insufficient permission"))
+	controller.ResponseErrorWithStatus(c, http.StatusForbidden, controller.CodeForbidden)
+	c.Abort()
+}
+
+// challenge 构造 WWW-Authenticate 响应头 如 Bearer realm="report", error="invalid_token", error_description="token expired"
+func challenge(scheme, errCode, desc string) string {
+	realm := settings.GetConf().Name
+	if realm == "" {
+		realm = "api"
+	}
+	v := fmt.Sprintf(`%s realm=%q`, scheme, quoteSafe(realm))
+	if errCode != "" {
+		v += fmt.Sprintf(`, error=%q`, errCode)
+	}
+	if desc != "" {
+		v += fmt.Sprintf(`, error_description=%q`, quoteSafe(desc))
+	}
+	return v
+}
+
+// quoteSafe 仅保留 RFC 6750 允许出现在引号内的可见 ASCII 字符
+func quoteSafe(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r < 0x20 || r > 0x7e || r == '"' || r == '\\' {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// JWTOrAPIKeyAuthMiddleware 同时接受 JWT 与 API Key 的认证中间件
+func JWTOrAPIKeyAuthMiddleware() gin.HandlerFunc {
+	return Authenticate(JWTAuthenticator, APIKeyAuthenticator)
+}
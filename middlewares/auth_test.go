@@ -0,0 +1,104 @@
+/**
+ * @Author: LiuShuXin
+ * @Description:
+ * @File:  auth_test
+ * Software: Goland
+ * @Date: 2026/10/18 20:15
+ */
+
+package middlewares
+
+import (
+	"app-server/controller"
+	"app-server/pkg/apikey"
+	"app-server/pkg/jwt"
+	"app-server/pkg/snowflake"
+	"app-server/settings"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+	if err := jwt.Init(&settings.Auth{JwtSecret: "test-secret"}, "test"); err != nil {
+		panic(err)
+	}
+	if err := snowflake.Init("2024-01-01", 1); err != nil {
+		panic(err)
+	}
+	m.Run()
+}
+
+func newAuthRouter() *gin.Engine {
+	r := gin.New()
+	r.GET("/me", JWTOrAPIKeyAuthMiddleware(), func(c *gin.Context) {
+		u, _ := controller.CurrentUser(c)
+		controller.ResponseSuccess(c, u)
+	})
+	return r
+}
+
+func doRequest(r http.Handler, header map[string]string) (*httptest.ResponseRecorder, controller.ResponseData) {
+	req := httptest.NewRequest(http.MethodGet, "/me", nil)
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	var res controller.ResponseData
+	_ = json.Unmarshal(w.Body.Bytes(), &res)
+	return w, res
+}
+
+func TestJWTOrAPIKeyAuth(t *testing.T) {
+	plain, hash := apikey.Generate()
+	if err := apikey.Init([]settings.APIKey{{Name: "batch", Hash: hash, Scopes: []string{"report:*"}}}); err != nil {
+		t.Fatal(err)
+	}
+	token, _ := jwt.GenToken(1, "tom", "user")
+	r := newAuthRouter()
+
+	cases := []struct {
+		name   string
+		header map[string]string
+		status int
+		want   controller.ResCode
+	}{
+		{"no credentials", nil, http.StatusUnauthorized, controller.CodeNeedLogin},
+		{"jwt", map[string]string{"Authorization": "Bearer " + token}, http.StatusOK, controller.CodeSuccess},
+		{"malformed jwt", map[string]string{"Authorization": "Bearer xxx"}, http.StatusUnauthorized, controller.CodeTokenMalformed},
+		{"forged jwt", map[string]string{"Authorization": "Bearer " + token[:len(token)-2] + "xx"}, http.StatusUnauthorized, controller.CodeTokenSignatureInvalid},
+		{"api key", map[string]string{APIKeyHeader: plain}, http.StatusOK, controller.CodeSuccess},
+		{"invalid api key", map[string]string{APIKeyHeader: "ak_xxx"}, http.StatusUnauthorized, controller.CodeInvalidAPIKey},
+	}
+	for _, c := range cases {
+		w, res := doRequest(r, c.header)
+		if w.Code != c.status || res.Code != c.want {
+			t.Errorf("%s: status = %d, code = %d, want %d, %d", c.name, w.Code, res.Code, c.status, c.want)
+		}
+		if c.status == http.StatusUnauthorized && w.Header().Get("WWW-Authenticate") == "" {
+			t.Errorf("%s: WWW-Authenticate header is missing", c.name)
+		}
+	}
+}
+
+func TestRevokedToken(t *testing.T) {
+	token, _ := jwt.GenToken(1, "tom", "user")
+	mc, _ := jwt.ParseToken(token)
+	if err := jwt.Revoke(context.Background(), mc); err != nil {
+		t.Fatal(err)
+	}
+	w, res := doRequest(newAuthRouter(), map[string]string{"Authorization": "Bearer " + token})
+	if w.Code != http.StatusUnauthorized || res.Code != controller.CodeTokenRevoked {
+		t.Errorf("status = %d, code = %d", w.Code, res.Code)
+	}
+	if got := w.Header().Get("WWW-Authenticate"); !strings.Contains(got, `error="invalid_token"`) {
+		t.Errorf("WWW-Authenticate = %s", got)
+	}
+}
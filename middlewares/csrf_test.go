@@ -0,0 +1,97 @@
+/**
+ * @Author: LiuShuXin
+ * @Description:
+ * @File:  csrf_test
+ * Software: Goland
+ * @Date: 2026/10/20 17:20
+ */
+
+package middlewares
+
+import (
+	"app-server/controller"
+	"app-server/pkg/jwt"
+	"app-server/settings"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestCookieCSRF(t *testing.T) {
+	if err := SetTokenLookup("header:Authorization,cookie:access_token"); err != nil {
+		t.Fatal(err)
+	}
+	defer SetTokenLookup(DefaultTokenLookup)
+	SetTrustedOrigins([]string{"https://admin.example.com/"})
+	defer SetTrustedOrigins(nil)
+
+	token, _ := jwt.GenToken(1, "tom", "user")
+	r := gin.New()
+	r.Any("/me", JWTAuthMiddleware(), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	cases := []struct {
+		name   string
+		method string
+		cookie bool
+		header map[string]string
+		status int
+	}{
+		{"cookie get", http.MethodGet, true, nil, http.StatusOK},
+		{"cookie post same origin", http.MethodPost, true, map[string]string{"Origin": "http://example.com"}, http.StatusOK},
+		{"cookie post trusted origin", http.MethodPost, true, map[string]string{"Origin": "https://admin.example.com"}, http.StatusOK},
+		{"cookie post referer", http.MethodPost, true, map[string]string{"Referer": "http://example.com/page"}, http.StatusOK},
+		{"cookie post cross site", http.MethodPost, true, map[string]string{"Origin": "https://evil.com"}, http.StatusForbidden},
+		{"cookie post no origin", http.MethodDelete, true, nil, http.StatusForbidden},
+		{"header post no origin", http.MethodPost, false, map[string]string{"Authorization": "Bearer " + token}, http.StatusOK},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest(tc.method, "http://example.com/me", nil)
+		if tc.cookie {
+			req.AddCookie(&http.Cookie{Name: "access_token", Value: token})
+		}
+		for k, v := range tc.header {
+			req.Header.Set(k, v)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if w.Code != tc.status {
+			t.Errorf("%s: status = %d, want %d", tc.name, w.Code, tc.status)
+		}
+	}
+}
+
+func TestRefreshTokenCookie(t *testing.T) {
+	conf := settings.GetConf()
+	defer func(auth *settings.Auth) { conf.Auth = auth }(conf.Auth)
+	conf.Auth = &settings.Auth{TokenCookie: &settings.TokenCookie{Enable: true, Name: "access_token"}}
+
+	r := gin.New()
+	r.POST("/auth/refresh", RefreshTokenCookie(), func(c *gin.Context) {
+		c.String(http.StatusOK, c.GetString(controller.CtxtRefreshTokenKey))
+	})
+	cases := []struct {
+		name   string
+		origin string
+		want   string
+	}{
+		{"same origin", "http://example.com", "r"},
+		{"cross site", "https://evil.com", ""},
+		{"no origin", "", ""},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest(http.MethodPost, "http://example.com/auth/refresh", nil)
+		req.AddCookie(&http.Cookie{Name: controller.RefreshCookieName(), Value: "r"})
+		if tc.origin != "" {
+			req.Header.Set("Origin", tc.origin)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if got := w.Body.String(); got != tc.want {
+			t.Errorf("%s: refresh token = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
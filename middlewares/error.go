@@ -0,0 +1,28 @@
+/**
+ * @Author: LiuShuXin
+ * @Description: 统一渲染处理函数通过 c.Error 附加的错误
+ * @File:  error
+ * Software: Goland
+ * @Date: 2026/10/18 21:30
+ */
+
+package middlewares
+
+import (
+	"app-server/controller"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ErrorHandler 处理函数只需 c.Error(err) 后返回 由此中间件统一输出响应
+// 已写出响应的请求不再处理 多个错误时以最后一个为准
+func ErrorHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+		controller.HandleError(c, c.Errors.Last().Err)
+	}
+}
@@ -0,0 +1,56 @@
+/**
+ * @Author: LiuShuXin
+ * @Description:
+ * @File:  error_test
+ * Software: Goland
+ * @Date: 2026/10/18 21:45
+ */
+
+package middlewares
+
+import (
+	"app-server/controller"
+	"app-server/pkg/errcode"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestErrorHandler(t *testing.T) {
+	controller.SetStrictHTTPStatus(true)
+	defer controller.SetStrictHTTPStatus(false)
+
+	cases := []struct {
+		name   string
+		err    error
+		status int
+		code   controller.ResCode
+	}{
+		{"app error", errcode.New(errcode.CodeInvalidParam).WithField("name", "必填"), http.StatusBadRequest, controller.CodeInvalidParam},
+		{"plain error", errors.New("db down"), http.StatusInternalServerError, controller.CodeServerBusy},
+	}
+	for _, tc := range cases {
+		r := gin.New()
+		r.Use(ErrorHandler())
+		r.GET("/me", func(c *gin.Context) {
+			_ = c.Error(tc.err)
+		})
+		w, res := doRequest(r, nil)
+		if w.Code != tc.status || res.Code != tc.code {
+			t.Errorf("%s: got %d/%d, want %d/%d", tc.name, w.Code, res.Code, tc.status, tc.code)
+		}
+	}
+
+	// 已写出响应时不再覆盖
+	r := gin.New()
+	r.Use(ErrorHandler())
+	r.GET("/me", func(c *gin.Context) {
+		_ = c.Error(errors.New("ignored"))
+		controller.ResponseSuccess(c, nil)
+	})
+	if w, res := doRequest(r, nil); w.Code != http.StatusOK || res.Code != controller.CodeSuccess {
+		t.Errorf("written response overridden: %d/%d", w.Code, res.Code)
+	}
+}
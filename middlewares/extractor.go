@@ -0,0 +1,116 @@
+/**
+ * @Author: LiuShuXin
+ * @Description: 从请求的不同位置获取 token
+ * @File:  extractor
+ * Software: Goland
+ * @Date: 2026/10/18 20:40
+ */
+
+package middlewares
+
+import (
+	"app-server/pkg/jwt"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"strings"
+)
+
+// DefaultTokenLookup 默认只从 Authorization 请求头获取
+const DefaultTokenLookup = "header:Authorization"
+
+// TokenExtractor 从请求中获取 token 未携带时返回空字符串
+type TokenExtractor func(c *gin.Context) (string, error)
+
+var tokenExtractors = []TokenExtractor{HeaderExtractor("Authorization")}
+
+// SetTokenLookup 设置获取 token 的位置及优先级 需在处理请求前调用
+// 格式为逗号分隔的 来源:名称，越靠前优先级越高，如
+// header:Authorization,cookie:access_token,query:token,form:token
+func SetTokenLookup(lookup string) error {
+	extractors, err := ParseTokenLookup(lookup)
+	if err != nil {
+		return err
+	}
+	tokenExtractors = extractors
+	return nil
+}
+
+// ParseTokenLookup 解析 token 获取位置
+func ParseTokenLookup(lookup string) ([]TokenExtractor, error) {
+	if lookup == "" {
+		lookup = DefaultTokenLookup
+	}
+	var extractors []TokenExtractor
+	for _, item := range strings.Split(lookup, ",") {
+		source, name, ok := strings.Cut(strings.TrimSpace(item), ":")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid token lookup: %q", item)
+		}
+		switch strings.TrimSpace(source) {
+		case "header":
+			extractors = append(extractors, HeaderExtractor(name))
+		case "cookie":
+			extractors = append(extractors, CookieExtractor(name))
+		case "query":
+			extractors = append(extractors, QueryExtractor(name))
+		case "form":
+			extractors = append(extractors, FormExtractor(name))
+		default:
+			return nil, fmt.Errorf("invalid token lookup source: %q", source)
+		}
+	}
+	return extractors, nil
+}
+
+// extractToken 按优先级依次尝试 返回第一个获取到的 token
+func extractToken(c *gin.Context) (string, error) {
+	for _, extract := range tokenExtractors {
+		token, err := extract(c)
+		if err != nil || token != "" {
+			return token, err
+		}
+	}
+	return "", nil
+}
+
+// HeaderExtractor 从请求头获取 使用 Bearer 开头，如 Authorization: Bearer xxx.xxx.xxx
+func HeaderExtractor(name string) TokenExtractor {
+	return func(c *gin.Context) (string, error) {
+		authHeader := c.Request.Header.Get(name)
+		if authHeader == "" {
+			return "", nil
+		}
+		// 按空格切割
+		parts := strings.SplitN(authHeader, " ", 2)
+		if !(len(parts) == 2 && parts[0] == "Bearer") {
+			return "", jwt.ErrInvalidToken
+		}
+		return parts[1], nil
+	}
+}
+
+// CookieExtractor 从 cookie 获取 浏览器页面使用 HttpOnly cookie 携带 token
+func CookieExtractor(name string) TokenExtractor {
+	return func(c *gin.Context) (string, error) {
+		token, _ := c.Cookie(name)
+		if token != "" {
+			c.Set(ctxtTokenFromCookie, true)
+		}
+		return token, nil
+	}
+}
+
+// QueryExtractor 从 URI 查询参数获取 如 websocket、文件下载等无法设置请求头的场景
+func QueryExtractor(name string) TokenExtractor {
+	return func(c *gin.Context) (string, error) {
+		return c.Query(name), nil
+	}
+}
+
+// FormExtractor 从表单请求体获取
+func FormExtractor(name string) TokenExtractor {
+	return func(c *gin.Context) (string, error) {
+		return c.PostForm(name), nil
+	}
+}
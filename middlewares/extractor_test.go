@@ -0,0 +1,69 @@
+/**
+ * @Author: LiuShuXin
+ * @Description:
+ * @File:  extractor_test
+ * Software: Goland
+ * @Date: 2026/10/18 21:15
+ */
+
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestExtractToken(t *testing.T) {
+	if err := SetTokenLookup("header:Authorization,cookie:access_token,query:token,form:token"); err != nil {
+		t.Fatalf("SetTokenLookup() err: %v", err)
+	}
+	defer SetTokenLookup(DefaultTokenLookup)
+
+	newRequest := func() *http.Request {
+		form := url.Values{"token": {"from-form"}}
+		req := httptest.NewRequest(http.MethodPost, "/?token=from-query", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.AddCookie(&http.Cookie{Name: "access_token", Value: "from-cookie"})
+		req.Header.Set("Authorization", "Bearer from-header")
+		return req
+	}
+	extract := func(req *http.Request) (string, error) {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = req
+		return extractToken(c)
+	}
+
+	req := newRequest()
+	if got, _ := extract(req); got != "from-header" {
+		t.Errorf("token = %s, want from-header", got)
+	}
+	req = newRequest()
+	req.Header.Del("Authorization")
+	if got, _ := extract(req); got != "from-cookie" {
+		t.Errorf("token = %s, want from-cookie", got)
+	}
+	req = newRequest()
+	req.Header.Del("Authorization")
+	req.Header.Del("Cookie")
+	if got, _ := extract(req); got != "from-query" {
+		t.Errorf("token = %s, want from-query", got)
+	}
+	req = newRequest()
+	req.Header.Set("Authorization", "Basic xxx")
+	if _, err := extract(req); err == nil {
+		t.Error("malformed Authorization header should fail")
+	}
+}
+
+func TestParseTokenLookupInvalid(t *testing.T) {
+	for _, lookup := range []string{"header", "body:token", "cookie:"} {
+		if _, err := ParseTokenLookup(lookup); err == nil {
+			t.Errorf("ParseTokenLookup(%q) should fail", lookup)
+		}
+	}
+}
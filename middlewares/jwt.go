@@ -12,37 +12,48 @@ import (
 	"app-server/controller"
 	"app-server/pkg/jwt"
 	"github.com/gin-gonic/gin"
-	"strings"
 )
 
 // JWTAuthMiddleware 基于 JWT 的认证中间件
 func JWTAuthMiddleware() func(c *gin.Context) {
-	return func(c *gin.Context) {
-		// 客户端携带 Token 的三种方式：1. 放在请求头 2. 放在请求体 3. 放在 URI
-		// 这里假设 Token 放在 Header 的 Authorization 中，并使用 Bearer 开头
-		// Authorization: Bearer xxx.xxx.xxx
-		authHeader := c.Request.Header.Get("Authorization")
-		if authHeader == "" {
-			controller.ResponseError(c, controller.CodeNeedLogin)
-			c.Abort()
-			return
-		}
-		// 按空格切割
-		parts := strings.SplitN(authHeader, " ", 2)
-		if !(len(parts) == 2 && parts[0] == "Bearer") {
-			controller.ResponseError(c, controller.CodeValidToken)
-			c.Abort()
-			return
-		}
-		// parts[1] 是获取的 tokenString, 我们使用之前定义好的解析 JWT 的函数来解析它
-		mc, err := jwt.ParseToken(parts[1])
-		if err != nil {
-			controller.ResponseError(c, controller.CodeValidToken)
-			c.Abort()
-			return
-		}
-		// 将当前请求的 userID 信息保存到请求的上下文 c 上
-		c.Set(controller.CtxtUserIDKey, mc.UserID)
-		c.Next() // 后续处理请求函数可以用 c.Get("username") 来获取当前请求的用户信息
+	return Authenticate(JWTAuthenticator)
+}
+
+// JWTAuthenticator 基于 JWT 的认证方式
+func JWTAuthenticator(c *gin.Context) (controller.UserInfo, error) {
+	// 客户端携带 Token 的方式：1. 放在请求头 2. 放在 cookie 3. 放在 URI 4. 放在表单请求体
+	// 按 SetTokenLookup 设置的优先级获取，默认只从 Authorization 请求头获取
+	tokenString, err := extractToken(c)
+	if err != nil {
+		return controller.UserInfo{}, &authError{code: controller.CodeTokenMalformed, err: err, scheme: schemeBearer}
+	}
+	if tokenString == "" {
+		return controller.UserInfo{}, errNoCredentials
+	}
+	// 浏览器跨站请求会自动带上 cookie 需确认请求来源
+	if err = checkOrigin(c); err != nil {
+		return controller.UserInfo{}, err
+	}
+	// 使用之前定义好的解析 JWT 的函数来解析它
+	mc, err := jwt.ParseToken(tokenString)
+	if err != nil {
+		return controller.UserInfo{}, &authError{code: controller.TokenErrorCode(err), err: err, scheme: schemeBearer}
+	}
+	// 检查 token 是否已被吊销（退出登录等）
+	revoked, err := jwt.IsRevoked(c.Request.Context(), mc)
+	if err != nil {
+		return controller.UserInfo{}, err
+	}
+	if revoked {
+		return controller.UserInfo{}, &authError{code: controller.CodeTokenRevoked, err: jwt.ErrTokenRevoked, scheme: schemeBearer}
 	}
+	// 将声明保存到请求的上下文 c 上 用户信息由 Authenticate 保存
+	// 后续处理请求函数可以用 controller.CurrentUser(c) 来获取当前请求的用户信息
+	c.Set(controller.CtxtClaimsKey, mc)
+	return controller.UserInfo{
+		ID:       mc.UserID,
+		Username: mc.Username,
+		Roles:    mc.Roles,
+		Scopes:   mc.Scopes,
+	}, nil
 }
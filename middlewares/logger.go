@@ -9,6 +9,8 @@
 package middlewares
 
 import (
+	"app-server/pkg/identity"
+	"app-server/pkg/logger"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 	"time"
@@ -25,8 +27,13 @@ func Logger() gin.HandlerFunc {
 
 		// 请求处理完成之后的一系列信息
 		cost := time.Since(start)
-		zap.L().Info(path,
+		var userID int64
+		if u, ok := identity.FromContext(c.Request.Context()); ok {
+			userID = u.ID
+		}
+		logger.WithContext(c.Request.Context()).Info(path,
 			zap.Int("status", c.Writer.Status()),
+			zap.Int64("user_id", userID),
 			zap.String("method", c.Request.Method),
 			zap.String("path", path),
 			zap.String("query", query),
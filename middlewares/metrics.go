@@ -0,0 +1,35 @@
+/**
+ * @Author: LiuShuXin
+ * @Description: 记录 HTTP 请求数、耗时及处理中的请求数
+ * @File:  metrics
+ * Software: Goland
+ * @Date: 2026/10/19 22:20
+ */
+
+package middlewares
+
+import (
+	"app-server/pkg/metrics"
+
+	"github.com/gin-gonic/gin"
+)
+
+// unmatchedRoute 未匹配到路由的请求统一归为一类 避免扫描器的随机路径产生大量指标
+const unmatchedRoute = "unmatched"
+
+// Metrics 按路由模板统计请求 指标路由本身不统计
+func Metrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := c.FullPath()
+		if !metrics.Enabled() || route == metrics.Path() {
+			c.Next()
+			return
+		}
+		if route == "" {
+			route = unmatchedRoute
+		}
+		done := metrics.StartRequest(c.Request.Method, route)
+		c.Next()
+		done(c.Writer.Status())
+	}
+}
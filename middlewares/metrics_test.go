@@ -0,0 +1,49 @@
+/**
+ * @Author: LiuShuXin
+ * @Description:
+ * @File:  metrics_test
+ * Software: Goland
+ * @Date: 2026/10/19 23:10
+ */
+
+package middlewares
+
+import (
+	"app-server/pkg/metrics"
+	"app-server/settings"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestMetricsRouteLabel(t *testing.T) {
+	if err := metrics.Init(&settings.Metrics{Enable: true, Namespace: "test"}, ""); err != nil {
+		t.Fatal(err)
+	}
+	r := gin.New()
+	r.Use(Metrics())
+	r.GET("/user/:id", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+	r.GET(metrics.Path(), gin.WrapH(metrics.Handler()))
+
+	for _, path := range []string{"/user/1", "/user/2", "/random/scan"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, metrics.Path(), nil))
+	body := w.Body.String()
+
+	for _, want := range []string{
+		`test_http_requests_total{method="GET",route="/user/:id",status="204"} 2`,
+		`test_http_requests_total{method="GET",route="unmatched",status="404"} 1`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("metrics missing %s", want)
+		}
+	}
+	if strings.Contains(body, `route="/metrics"`) {
+		t.Error("metrics route should not be recorded")
+	}
+}
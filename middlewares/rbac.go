@@ -0,0 +1,61 @@
+/**
+ * @Author: LiuShuXin
+ * @Description:
+ * @File:  rbac
+ * Software: Goland
+ * @Date: 2026/10/18 17:55
+ */
+
+package middlewares
+
+import (
+	"app-server/controller"
+	"app-server/pkg/rbac"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// RequireRoles 要求当前用户拥有任一指定角色 需放在 JWTAuthMiddleware 之后
+func RequireRoles(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		u, ok := currentUser(c)
+		if !ok {
+			return
+		}
+		if !rbac.HasAnyRole(u.Roles, roles...) {
+			abortForbidden(c)
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequirePermission 要求当前用户拥有全部指定权限 需放在 JWTAuthMiddleware 之后
+// 权限由角色按策略授予，或由 token 的 scopes 直接授予
+func RequirePermission(perms ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		u, ok := currentUser(c)
+		if !ok {
+			return
+		}
+		for _, perm := range perms {
+			if !rbac.Allowed(u.Roles, perm) && !rbac.Granted(u.Scopes, perm) {
+				abortForbidden(c)
+				return
+			}
+		}
+		c.Next()
+	}
+}
+
+// currentUser 获取认证中间件保存的当前用户 不存在时直接响应需要登录
+func currentUser(c *gin.Context) (controller.UserInfo, bool) {
+	u, err := controller.CurrentUser(c)
+	if err != nil {
+		c.Header("WWW-Authenticate", challenge(schemeBearer, "", ""))
+		controller.ResponseErrorWithStatus(c, http.StatusUnauthorized, controller.CodeNeedLogin)
+		c.Abort()
+		return u, false
+	}
+	return u, true
+}
@@ -9,6 +9,7 @@
 package middlewares
 
 import (
+	"app-server/pkg/logger"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 	"net"
@@ -36,8 +37,9 @@ func Recovery(stack bool) gin.HandlerFunc {
 				}
 
 				httpRequest, _ := httputil.DumpRequest(c.Request, false)
+				lg := logger.WithContext(c.Request.Context())
 				if brokenPipe {
-					zap.L().Error(c.Request.URL.Path,
+					lg.Error(c.Request.URL.Path,
 						zap.Any("e", err),
 						zap.String("request", string(httpRequest)),
 					)
@@ -48,13 +50,13 @@ func Recovery(stack bool) gin.HandlerFunc {
 				}
 
 				if stack {
-					zap.L().Error("[Recovery from panic]",
+					lg.Error("[Recovery from panic]",
 						zap.Any("e", err),
 						zap.String("request", string(httpRequest)),
 						zap.String("stack", string(debug.Stack())),
 					)
 				} else {
-					zap.L().Error("[Recovery from panic]",
+					lg.Error("[Recovery from panic]",
 						zap.Any("e", err),
 						zap.String("request", string(httpRequest)),
 					)
@@ -0,0 +1,33 @@
+/**
+ * @Author: LiuShuXin
+ * @Description: 为每个请求分配请求 ID
+ * @File:  requestid
+ * Software: Goland
+ * @Date: 2026/10/19 18:10
+ */
+
+package middlewares
+
+import (
+	"app-server/controller"
+	"app-server/pkg/requestid"
+	"app-server/pkg/snowflake"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestID 沿用上游传入的 X-Request-ID 否则使用雪花 ID 生成
+// 请求 ID 写入 gin 上下文、请求 context 及响应头 需放在 Logger、Recovery 之前
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(requestid.Header)
+		if !requestid.Valid(id) {
+			id = strconv.FormatInt(snowflake.GenID(), 10)
+		}
+		c.Set(controller.CtxtRequestIDKey, id)
+		c.Request = c.Request.WithContext(requestid.NewContext(c.Request.Context(), id))
+		c.Header(requestid.Header, id)
+		c.Next()
+	}
+}
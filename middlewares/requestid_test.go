@@ -0,0 +1,50 @@
+/**
+ * @Author: LiuShuXin
+ * @Description:
+ * @File:  requestid_test
+ * Software: Goland
+ * @Date: 2026/10/19 18:50
+ */
+
+package middlewares
+
+import (
+	"app-server/controller"
+	"app-server/pkg/requestid"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRequestID(t *testing.T) {
+	r := gin.New()
+	r.Use(RequestID())
+	r.GET("/me", func(c *gin.Context) {
+		if got := requestid.FromContext(c.Request.Context()); got != controller.RequestID(c) {
+			t.Errorf("context request id = %q, gin = %q", got, controller.RequestID(c))
+		}
+		controller.ResponseSuccess(c, nil)
+	})
+
+	cases := []struct {
+		name   string
+		header string
+		keep   bool
+	}{
+		{"upstream", "req-abc-123", true},
+		{"generated", "", false},
+		{"invalid", "bad id\n", false},
+		{"too long", strings.Repeat("a", 200), false},
+	}
+	for _, tc := range cases {
+		w, res := doRequest(r, map[string]string{requestid.Header: tc.header})
+		id := w.Header().Get(requestid.Header)
+		if id == "" || res.RequestID != id {
+			t.Errorf("%s: header = %q, body = %q", tc.name, id, res.RequestID)
+		}
+		if tc.keep != (id == tc.header) {
+			t.Errorf("%s: request id = %q", tc.name, id)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+/**
+ * @Author: LiuShuXin
+ * @Description:
+ * @File:  tracing_test
+ * Software: Goland
+ * @Date: 2026/10/19 21:10
+ */
+
+package middlewares
+
+import (
+	"app-server/controller"
+	"app-server/pkg/logger"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.uber.org/zap"
+)
+
+func TestTracing(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr)))
+	defer otel.SetTracerProvider(prev)
+
+	var fields []zap.Field
+	r := gin.New()
+	r.Use(Tracing())
+	r.GET("/me", func(c *gin.Context) {
+		fields = logger.ContextFields(c.Request.Context())
+		controller.ResponseErrorWithStatus(c, http.StatusInternalServerError, controller.CodeServerBusy)
+	})
+
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	doRequest(r, map[string]string{"traceparent": "00-" + traceID + "-00f067aa0ba902b7-01"})
+
+	spans := sr.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("spans = %d, want 1", len(spans))
+	}
+	s := spans[0]
+	if s.Name() != "GET /me" || s.SpanContext().TraceID().String() != traceID || s.Parent().SpanID().String() != "00f067aa0ba902b7" {
+		t.Errorf("span = %s trace=%s parent=%s", s.Name(), s.SpanContext().TraceID(), s.Parent().SpanID())
+	}
+	var status int64
+	for _, a := range s.Attributes() {
+		if a.Key == semconv.HTTPResponseStatusCodeKey {
+			status = a.Value.AsInt64()
+		}
+	}
+	if status != http.StatusInternalServerError || s.Status().Code.String() != "Error" {
+		t.Errorf("status attr = %d, span status = %v", status, s.Status())
+	}
+
+	got := map[string]string{}
+	for _, f := range fields {
+		got[f.Key] = f.String
+	}
+	if got["trace_id"] != traceID || got["span_id"] != s.SpanContext().SpanID().String() {
+		t.Errorf("log fields = %v", got)
+	}
+}
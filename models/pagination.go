@@ -1,6 +1,11 @@
 package models
 
+// Pagination 列表接口的分页及排序参数
+// 偏移分页使用 pagenum/pagesize 游标分页使用 cursor/pagesize 上一页响应中的 next_cursor 即为下一页的 cursor
 type Pagination struct {
-	PageNum  int `form:"pagenum"`
-	PageSize int `form:"pagesize"`
+	PageNum  int    `form:"pagenum" json:"pagenum"`
+	PageSize int    `form:"pagesize" json:"pagesize"`
+	Sort     string `form:"sort" json:"sort"`   // 排序字段 需在接口允许的字段内
+	Order    string `form:"order" json:"order"` // asc | desc
+	Cursor   string `form:"cursor" json:"cursor"`
 }
@@ -1 +1,30 @@
 package models
+
+// ParamSignUp 注册请求参数
+type ParamSignUp struct {
+	Username   string `json:"username" binding:"required,min=3,max=32,username"`
+	Password   string `json:"password" binding:"required,min=6,max=64,maxbytes=72"` // bcrypt 只接受不超过 72 字节的密码
+	RePassword string `json:"re_password" binding:"required,eqfield=Password"`
+}
+
+// ParamLogin 登录请求参数
+type ParamLogin struct {
+	Username string `json:"username" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+// ParamRefreshToken 刷新 token 请求参数 开启 cookie 认证时浏览器页面可不传 改用 cookie 中的 refresh token
+type ParamRefreshToken struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// ParamLogout 退出登录请求参数 携带 refresh token 时一并作废
+type ParamLogout struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// ParamList 列表接口通用参数 Filter 形如 status:eq:active,created_at:gt:2025-01-01
+type ParamList struct {
+	Pagination
+	Filter string `form:"filter" json:"filter"`
+}
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
+// User 用户
+type User struct {
+	UserID     int64     `db:"user_id" json:"user_id,string"`
+	Username   string    `db:"username" json:"username"`
+	Password   string    `db:"password" json:"-"` // bcrypt 哈希
+	Role       string    `db:"role" json:"role"`
+	Disabled   bool      `db:"disabled" json:"disabled"` // 被禁用的用户不能登录及刷新 token
+	CreateTime time.Time `db:"create_time" json:"create_time"`
+}
@@ -0,0 +1,145 @@
+/**
+ * @Author: LiuShuXin
+ * @Description: API Key 认证 用于批处理任务等服务间调用
+ * @File:  apikey
+ * Software: Goland
+ * @Date: 2026/10/18 19:10
+ */
+
+package apikey
+
+import (
+	"app-server/settings"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+const keyPrefix = "ak_"
+
+var (
+	ErrInvalidKey = errors.New("invalid api key")
+	ErrExpiredKey = errors.New("api key expired")
+)
+
+// Key API Key 只保存明文的 sha256 哈希
+type Key struct {
+	Name      string    `json:"name"`
+	Hash      string    `json:"hash"`
+	Scopes    []string  `json:"scopes"`
+	ExpiresAt time.Time `json:"expires_at"` // 零值表示永不过期
+}
+
+// Expired 是否已过期
+func (k *Key) Expired() bool {
+	return !k.ExpiresAt.IsZero() && time.Now().After(k.ExpiresAt)
+}
+
+// Store API Key 存储 以哈希为键查询 不存在时返回 ErrInvalidKey
+type Store interface {
+	Get(ctx context.Context, hash string) (*Key, error)
+}
+
+var store Store = NewMemoryStore()
+
+// SetStore 替换 API Key 存储 需在处理请求前调用
+func SetStore(s Store) {
+	store = s
+}
+
+// Init 将配置文件中声明的 API Key 加载到内存存储
+func Init(keys []settings.APIKey) error {
+	s := NewMemoryStore()
+	for _, k := range keys {
+		// Hash 输出小写十六进制 统一大小写后比较
+		key := Key{Name: k.Name, Hash: strings.ToLower(strings.TrimSpace(k.Hash)), Scopes: k.Scopes}
+		if k.ExpiresAt != "" {
+			t, err := parseExpiresAt(k.ExpiresAt)
+			if err != nil {
+				return fmt.Errorf("api key %s: invalid expires_at: %w", k.Name, err)
+			}
+			key.ExpiresAt = t
+		}
+		if b, err := hex.DecodeString(key.Hash); err != nil || len(b) != sha256.Size {
+			return fmt.Errorf("api key %s: hash must be sha256 hex", k.Name)
+		}
+		s.Add(key)
+	}
+	SetStore(s)
+	return nil
+}
+
+// parseExpiresAt 支持 RFC3339 时间或日期 日期表示当天结束（本地时间）前有效
+func parseExpiresAt(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	day, err := time.ParseInLocation("2006-01-02", s, time.Local)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return day.AddDate(0, 0, 1).Add(-time.Nanosecond), nil
+}
+
+// Generate 生成新的 API Key 返回明文及其哈希 明文只应交付给调用方，服务端仅保存哈希
+func Generate() (plain, hash string) {
+	b := make([]byte, 32)
+	_, _ = rand.Read(b)
+	plain = keyPrefix + base64.RawURLEncoding.EncodeToString(b)
+	return plain, Hash(plain)
+}
+
+// Hash 计算 API Key 的哈希
+func Hash(plain string) string {
+	sum := sha256.Sum256([]byte(plain))
+	return hex.EncodeToString(sum[:])
+}
+
+// Verify 校验明文 API Key 返回对应的 Key
+func Verify(ctx context.Context, plain string) (*Key, error) {
+	if plain == "" {
+		return nil, ErrInvalidKey
+	}
+	key, err := store.Get(ctx, Hash(plain))
+	if err != nil {
+		return nil, err
+	}
+	if key.Expired() {
+		return nil, ErrExpiredKey
+	}
+	return key, nil
+}
+
+// MemoryStore 进程内存实现
+type MemoryStore struct {
+	mu   sync.RWMutex
+	keys map[string]Key // hash -> key
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{keys: make(map[string]Key)}
+}
+
+// Add 添加 API Key
+func (s *MemoryStore) Add(key Key) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys[key.Hash] = key
+}
+
+func (s *MemoryStore) Get(_ context.Context, hash string) (*Key, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	key, ok := s.keys[hash]
+	if !ok {
+		return nil, ErrInvalidKey
+	}
+	return &key, nil
+}
@@ -0,0 +1,80 @@
+/**
+ * @Author: LiuShuXin
+ * @Description:
+ * @File:  apikey_test
+ * Software: Goland
+ * @Date: 2026/10/18 20:05
+ */
+
+package apikey
+
+import (
+	"app-server/settings"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestVerify(t *testing.T) {
+	active, activeHash := Generate()
+	expired, expiredHash := Generate()
+	err := Init([]settings.APIKey{
+		{Name: "batch", Hash: activeHash, Scopes: []string{"report:*"}},
+		{Name: "old", Hash: expiredHash, ExpiresAt: "2020-01-01"},
+	})
+	if err != nil {
+		t.Fatalf("Init() err: %v", err)
+	}
+	ctx := context.Background()
+
+	key, err := Verify(ctx, active)
+	if err != nil || key.Name != "batch" || len(key.Scopes) != 1 {
+		t.Errorf("Verify() = %+v, %v", key, err)
+	}
+	if _, err = Verify(ctx, expired); !errors.Is(err, ErrExpiredKey) {
+		t.Errorf("Verify() expired err = %v, want %v", err, ErrExpiredKey)
+	}
+	if _, err = Verify(ctx, "ak_unknown"); !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("Verify() unknown err = %v, want %v", err, ErrInvalidKey)
+	}
+}
+
+func TestInitRejectsPlainKey(t *testing.T) {
+	plain, _ := Generate()
+	if err := Init([]settings.APIKey{{Name: "bad", Hash: plain}}); err == nil {
+		t.Error("Init() should reject non-sha256 hash")
+	}
+	if err := Init([]settings.APIKey{{Name: "bad", Hash: strings.Repeat("z", 64)}}); err == nil {
+		t.Error("Init() should reject non-hex hash")
+	}
+}
+
+func TestInitUppercaseHash(t *testing.T) {
+	plain, hash := Generate()
+	if err := Init([]settings.APIKey{{Name: "upper", Hash: strings.ToUpper(hash)}}); err != nil {
+		t.Fatalf("Init() err: %v", err)
+	}
+	if key, err := Verify(context.Background(), plain); err != nil || key.Name != "upper" {
+		t.Errorf("Verify() = %+v, %v", key, err)
+	}
+}
+
+func TestParseExpiresAt(t *testing.T) {
+	// 日期表示当天结束前有效
+	got, err := parseExpiresAt("2027-12-31")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2027, 12, 31, 23, 59, 59, 999999999, time.Local); !got.Equal(want) {
+		t.Errorf("date = %v, want %v", got, want)
+	}
+	got, err = parseExpiresAt("2027-12-31T08:00:00Z")
+	if err != nil || !got.Equal(time.Date(2027, 12, 31, 8, 0, 0, 0, time.UTC)) {
+		t.Errorf("rfc3339 = %v, %v", got, err)
+	}
+	if _, err = parseExpiresAt("31/12/2027"); err == nil {
+		t.Error("invalid format should fail")
+	}
+}
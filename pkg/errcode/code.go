@@ -0,0 +1,78 @@
+/**
+ * @Author: LiuShuXin
+ * @Description: 业务状态码 放在 pkg 下以便 logic、dao 层返回携带状态码的错误
+ * @File:  code
+ * Software: Goland
+ * @Date: 2025/1/21 10:29
+ */
+
+package errcode
+
+import "net/http"
+
+type ResCode int64
+
+const (
+	CodeSuccess ResCode = 1000 + iota
+	CodeInvalidParam
+	CodeUserExist
+	CodeUserNotExist
+	CodeInvalidPassword
+	CodeServerBusy
+
+	CodeNeedLogin
+	CodeInvalidToken
+	CodeForbidden
+	CodeInvalidAPIKey
+	CodeTokenExpired
+	CodeTokenNotValidYet
+	CodeTokenMalformed
+	CodeTokenSignatureInvalid
+	CodeTokenRevoked
+	CodeNotFound
+	CodeMethodNotAllowed
+	CodeUserDisabled
+)
+
+// fallbackMsgs 语言包加载前的兜底提示 完整的中文提示只维护在 conf/i18n/zh-CN.yaml 中
+var fallbackMsgs = map[ResCode]string{
+	CodeSuccess:    "success",
+	CodeServerBusy: "服务繁忙",
+}
+
+// codeStatusMap 业务状态码对应的 HTTP 状态码 未列出的错误码视为服务端错误
+var codeStatusMap = map[ResCode]int{
+	CodeSuccess:         http.StatusOK,
+	CodeInvalidParam:    http.StatusBadRequest,
+	CodeUserExist:       http.StatusConflict,
+	CodeUserNotExist:    http.StatusNotFound,
+	CodeInvalidPassword: http.StatusUnauthorized,
+	CodeServerBusy:      http.StatusInternalServerError,
+	CodeNeedLogin:       http.StatusUnauthorized,
+	CodeInvalidToken:    http.StatusUnauthorized,
+	CodeForbidden:       http.StatusForbidden,
+	CodeInvalidAPIKey:   http.StatusUnauthorized,
+
+	CodeTokenExpired:          http.StatusUnauthorized,
+	CodeTokenNotValidYet:      http.StatusUnauthorized,
+	CodeTokenMalformed:        http.StatusUnauthorized,
+	CodeTokenSignatureInvalid: http.StatusUnauthorized,
+	CodeTokenRevoked:          http.StatusUnauthorized,
+	CodeNotFound:              http.StatusNotFound,
+	CodeMethodNotAllowed:      http.StatusMethodNotAllowed,
+	CodeUserDisabled:          http.StatusForbidden,
+}
+
+// Msg 默认语言的提示 按请求语言返回提示见 MsgFor
+func (rc ResCode) Msg() string {
+	return rc.MsgFor(DefaultLocale)
+}
+
+// HTTPStatus 业务状态码对应的 HTTP 状态码
+func (rc ResCode) HTTPStatus() int {
+	status, ok := codeStatusMap[rc]
+	if !ok {
+		status = http.StatusInternalServerError
+	}
+	return status
+}
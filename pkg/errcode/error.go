@@ -0,0 +1,181 @@
+/**
+ * @Author: LiuShuXin
+ * @Description: 携带业务状态码的应用错误 由 controller.HandleError 统一转换为响应
+ * @File:  error
+ * Software: Goland
+ * @Date: 2026/10/18 21:05
+ */
+
+package errcode
+
+import (
+	"errors"
+	"fmt"
+)
+
+// AppError 应用错误
+// Message 为空时使用状态码对应的默认提示 Details 一般用于字段级错误 如 {"username": "不能为空"}
+type AppError struct {
+	Code    ResCode
+	Message string
+	Details map[string]string
+	Cause   error
+
+	localized map[string]LocalizedMsg // 字段 -> 多语言提示 由 DetailsFor 按语言展开
+}
+
+// LocalizedMsg 多语言提示 语言标签 -> 提示 需包含 DefaultLocale
+type LocalizedMsg map[string]string
+
+// Bilingual 中英文提示 args 同时用于两种语言的格式化
+func Bilingual(zh, en string, args ...interface{}) LocalizedMsg {
+	return LocalizedMsg{
+		DefaultLocale: fmt.Sprintf(zh, args...),
+		"en-US":       fmt.Sprintf(en, args...),
+	}
+}
+
+// For 指定语言的提示 缺少该语言时使用默认语言
+func (m LocalizedMsg) For(locale string) string {
+	if msg, ok := m[locale]; ok {
+		return msg
+	}
+	return m[DefaultLocale]
+}
+
+// New 创建指定状态码的错误
+func New(code ResCode) *AppError {
+	return &AppError{Code: code}
+}
+
+// Newf 创建带自定义提示的错误
+func Newf(code ResCode, format string, args ...interface{}) *AppError {
+	return &AppError{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// Wrap 包装底层错误 err 为 nil 时返回 nil
+// err 本身是状态码相同的 AppError 时保留其提示与字段信息
+// 状态码不同时不沿用 避免内层的提示（如数据访问层的错误信息）以新状态码返回给客户端
+func Wrap(err error, code ResCode) *AppError {
+	if err == nil {
+		return nil
+	}
+	e := &AppError{Code: code, Cause: err}
+	var ae *AppError
+	if errors.As(err, &ae) && ae.Code == code {
+		e.Message = ae.Message
+		e.Details = ae.Details
+		e.localized = ae.localized
+	}
+	return e
+}
+
+// From 从错误链中取出 AppError 不存在时返回 false
+func From(err error) (*AppError, bool) {
+	var ae *AppError
+	if errors.As(err, &ae) {
+		return ae, true
+	}
+	return nil, false
+}
+
+// WithMessage 返回替换提示后的副本 不修改原错误 以便包级哨兵错误可安全复用
+func (e *AppError) WithMessage(msg string) *AppError {
+	c := e.clone()
+	c.Message = msg
+	return c
+}
+
+// WithCause 返回附带底层错误的副本
+func (e *AppError) WithCause(err error) *AppError {
+	c := e.clone()
+	c.Cause = err
+	return c
+}
+
+// WithField 返回追加字段错误后的副本
+func (e *AppError) WithField(field, msg string) *AppError {
+	c := e.clone()
+	c.Details[field] = msg
+	delete(c.localized, field)
+	return c
+}
+
+// WithLocalizedField 返回追加按请求语言返回的字段错误后的副本 Details 中保存默认语言的提示
+func (e *AppError) WithLocalizedField(field string, msg LocalizedMsg) *AppError {
+	c := e.clone()
+	c.Details[field] = msg.For(DefaultLocale)
+	c.localized[field] = msg
+	return c
+}
+
+// WithDetails 返回追加多个字段错误后的副本
+func (e *AppError) WithDetails(details map[string]string) *AppError {
+	c := e.clone()
+	for k, v := range details {
+		c.Details[k] = v
+		delete(c.localized, k)
+	}
+	return c
+}
+
+// DetailsFor 指定语言的字段错误
+func (e *AppError) DetailsFor(locale string) map[string]string {
+	if len(e.localized) == 0 {
+		return e.Details
+	}
+	details := make(map[string]string, len(e.Details))
+	for k, v := range e.Details {
+		details[k] = v
+	}
+	for k, msg := range e.localized {
+		details[k] = msg.For(locale)
+	}
+	return details
+}
+
+// Msg 返回对外展示的提示
+func (e *AppError) Msg() string {
+	return e.MsgFor(DefaultLocale)
+}
+
+// MsgFor 指定语言的提示 自定义提示不做翻译
+func (e *AppError) MsgFor(locale string) string {
+	if e.Message != "" {
+		return e.Message
+	}
+	return e.Code.MsgFor(locale)
+}
+
+func (e *AppError) Error() string {
+	if e.Cause != nil {
+		return fmt.Sprintf("code=%d msg=%s: %v", e.Code, e.Msg(), e.Cause)
+	}
+	return fmt.Sprintf("code=%d msg=%s", e.Code, e.Msg())
+}
+
+func (e *AppError) Unwrap() error {
+	return e.Cause
+}
+
+// Is 状态码相同即视为同一错误 使 errors.Is(err, dao.ErrUserExist) 对副本同样成立
+func (e *AppError) Is(target error) bool {
+	t, ok := target.(*AppError)
+	if !ok {
+		return false
+	}
+	return e.Code == t.Code
+}
+
+func (e *AppError) clone() *AppError {
+	c := *e
+	c.Details = make(map[string]string, len(e.Details)+1)
+	for k, v := range e.Details {
+		c.Details[k] = v
+	}
+	c.localized = make(map[string]LocalizedMsg, len(e.localized)+1)
+	for k, v := range e.localized {
+		c.localized[k] = v
+	}
+	return &c
+}
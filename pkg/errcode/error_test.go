@@ -0,0 +1,79 @@
+/**
+ * @Author: LiuShuXin
+ * @Description:
+ * @File:  error_test
+ * Software: Goland
+ * @Date: 2026/10/18 21:40
+ */
+
+package errcode
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+var errUserExist = New(CodeUserExist)
+
+func TestAppErrorIsAs(t *testing.T) {
+	cause := errors.New("duplicate entry")
+	err := fmt.Errorf("insert user: %w", errUserExist.WithCause(cause).WithField("username", "已被占用"))
+
+	if !errors.Is(err, errUserExist) {
+		t.Fatal("errors.Is should match by code")
+	}
+	if errors.Is(err, New(CodeUserNotExist)) {
+		t.Fatal("errors.Is should not match a different code")
+	}
+	if !errors.Is(err, cause) {
+		t.Fatal("errors.Is should reach the wrapped cause")
+	}
+	ae, ok := From(err)
+	if !ok || ae.Code != CodeUserExist || ae.Details["username"] != "已被占用" {
+		t.Fatalf("From() = %+v, %v", ae, ok)
+	}
+	// 哨兵错误不应被 With* 修改
+	if errUserExist.Cause != nil || len(errUserExist.Details) != 0 {
+		t.Fatalf("sentinel modified: %+v", errUserExist)
+	}
+}
+
+func TestWrapAndMsg(t *testing.T) {
+	if Wrap(nil, CodeServerBusy) != nil {
+		t.Fatal("Wrap(nil) should return nil")
+	}
+	inner := New(CodeInvalidParam).WithMessage("page 必须大于 0").WithField("page", "必须大于 0")
+	e := Wrap(inner, CodeInvalidParam)
+	if e.Code != CodeInvalidParam || e.Msg() != "page 必须大于 0" || e.Details["page"] == "" {
+		t.Fatalf("Wrap() same code = %+v", e)
+	}
+	// 状态码不同时使用新状态码的提示
+	e = Wrap(inner, CodeServerBusy)
+	if e.Code != CodeServerBusy || e.Msg() != CodeServerBusy.Msg() || len(e.Details) != 0 || !errors.Is(e, inner) {
+		t.Fatalf("Wrap() new code = %+v", e)
+	}
+	if got := New(CodeUserNotExist).Msg(); got != CodeUserNotExist.Msg() {
+		t.Fatalf("Msg() = %q, want default", got)
+	}
+}
+
+func TestLocalizedField(t *testing.T) {
+	e := New(CodeInvalidParam).
+		WithLocalizedField("age", LocalizedMsg{DefaultLocale: "不是整数", "en-US": "not an integer"}).
+		WithField("name", "不能为空")
+	if e.Details["age"] != "不是整数" {
+		t.Errorf("Details[age] = %q, want default locale", e.Details["age"])
+	}
+	en := e.DetailsFor("en-US")
+	if en["age"] != "not an integer" || en["name"] != "不能为空" {
+		t.Errorf("DetailsFor(en-US) = %v", en)
+	}
+	if got := e.DetailsFor("ja-JP")["age"]; got != "不是整数" {
+		t.Errorf("DetailsFor(ja-JP)[age] = %q, want default locale", got)
+	}
+	// 同名字段被普通提示覆盖后不再翻译
+	if got := e.WithField("age", "x").DetailsFor("en-US")["age"]; got != "x" {
+		t.Errorf("overridden detail = %q", got)
+	}
+}
@@ -0,0 +1,137 @@
+/**
+ * @Author: LiuShuXin
+ * @Description: 状态码提示的多语言支持 语言包为 conf/i18n 下以语言标签命名的 yaml 文件 如 en-US.yaml
+ * @File:  i18n
+ * Software: Goland
+ * @Date: 2026/10/18 22:30
+ */
+
+package errcode
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/spf13/cast"
+	"github.com/spf13/viper"
+	"golang.org/x/text/language"
+)
+
+// DefaultLocale 默认语言 未匹配到语言或语言包中缺少对应状态码时使用
+const DefaultLocale = "zh-CN"
+
+type catalog struct {
+	msgs    map[string]map[ResCode]string // 语言 -> 状态码 -> 提示
+	locales []string                      // 与 matcher 中的语言顺序一致 第一个为默认语言
+	matcher language.Matcher
+}
+
+var catalogs atomic.Pointer[catalog]
+
+func init() {
+	// 未加载语言包时 仅有内置的兜底提示
+	catalogs.Store(newCatalog(map[string]map[ResCode]string{DefaultLocale: fallbackMsgs}))
+}
+
+// LoadCatalogs 加载目录下的全部语言包 文件名(不含扩展名)即语言标签
+// 文件内容为 状态码: 提示 如 1001: "Invalid parameters" 目录下必须包含默认语言的语言包
+func LoadCatalogs(dir string) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*.yaml"))
+	if err != nil {
+		return err
+	}
+	msgs := map[string]map[ResCode]string{DefaultLocale: fallbackMsgs}
+	loadedDefault := false
+	for _, file := range files {
+		locale := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+		tag, err := language.Parse(locale)
+		if err != nil {
+			return fmt.Errorf("i18n: invalid locale %s: %w", locale, err)
+		}
+		m, err := loadCatalog(file)
+		if err != nil {
+			return err
+		}
+		locale = tag.String()
+		loadedDefault = loadedDefault || locale == DefaultLocale
+		if base, ok := msgs[locale]; ok {
+			// 与内置兜底提示合并 文件中的优先
+			for code, msg := range base {
+				if _, ok := m[code]; !ok {
+					m[code] = msg
+				}
+			}
+		}
+		msgs[locale] = m
+	}
+	if !loadedDefault {
+		return fmt.Errorf("i18n: %s.yaml not found in %s", DefaultLocale, dir)
+	}
+	catalogs.Store(newCatalog(msgs))
+	return nil
+}
+
+func loadCatalog(file string) (map[ResCode]string, error) {
+	v := viper.New()
+	v.SetConfigFile(file)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("i18n: read %s: %w", file, err)
+	}
+	m := make(map[ResCode]string)
+	for k, val := range v.AllSettings() {
+		code, err := strconv.ParseInt(k, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("i18n: %s: invalid code %q", file, k)
+		}
+		m[ResCode(code)] = cast.ToString(val)
+	}
+	return m, nil
+}
+
+func newCatalog(msgs map[string]map[ResCode]string) *catalog {
+	locales := []string{DefaultLocale}
+	for locale := range msgs {
+		if locale != DefaultLocale {
+			locales = append(locales, locale)
+		}
+	}
+	tags := make([]language.Tag, len(locales))
+	for i, l := range locales {
+		tags[i] = language.Make(l)
+	}
+	return &catalog{msgs: msgs, locales: locales, matcher: language.NewMatcher(tags)}
+}
+
+// Negotiate 根据 Accept-Language 选择已加载的语言 无法匹配时返回 DefaultLocale
+func Negotiate(acceptLanguage string) string {
+	c := catalogs.Load()
+	if acceptLanguage == "" {
+		return DefaultLocale
+	}
+	_, idx := language.MatchStrings(c.matcher, acceptLanguage)
+	return c.locales[idx]
+}
+
+// Locales 已加载的语言
+func Locales() []string {
+	c := catalogs.Load()
+	return append([]string(nil), c.locales...)
+}
+
+// MsgFor 指定语言的提示 语言包缺少该状态码时回退到默认语言的提示
+func (rc ResCode) MsgFor(locale string) string {
+	c := catalogs.Load()
+	if msg, ok := c.msgs[locale][rc]; ok {
+		return msg
+	}
+	if msg, ok := c.msgs[DefaultLocale][rc]; ok {
+		return msg
+	}
+	if rc != CodeServerBusy {
+		return CodeServerBusy.MsgFor(locale)
+	}
+	return fallbackMsgs[CodeServerBusy]
+}
@@ -0,0 +1,101 @@
+/**
+ * @Author: LiuShuXin
+ * @Description:
+ * @File:  i18n_test
+ * Software: Goland
+ * @Date: 2026/10/18 23:00
+ */
+
+package errcode
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadCatalogs(t *testing.T) {
+	if err := LoadCatalogs("../../conf/i18n"); err != nil {
+		t.Fatal(err)
+	}
+	defer resetCatalogs()
+
+	cases := []struct {
+		header string
+		want   string
+	}{
+		{"", DefaultLocale},
+		{"en", "en-US"},
+		{"en-GB,en;q=0.9", "en-US"},
+		{"zh-CN,zh;q=0.9,en;q=0.8", "zh-CN"},
+		{"fr-FR", DefaultLocale},
+		{"!!invalid", DefaultLocale},
+	}
+	for _, c := range cases {
+		if got := Negotiate(c.header); got != c.want {
+			t.Errorf("Negotiate(%q) = %q, want %q", c.header, got, c.want)
+		}
+	}
+
+	if got := CodeInvalidParam.MsgFor("en-US"); got != "Invalid request parameters" {
+		t.Errorf("MsgFor(en-US) = %q", got)
+	}
+	if got := CodeInvalidParam.MsgFor("fr-FR"); got != "请求参数错误" {
+		t.Errorf("MsgFor(fr-FR) = %q, want zh-CN fallback", got)
+	}
+	if got := ResCode(9999).MsgFor("en-US"); got != "Server is busy" {
+		t.Errorf("unknown code MsgFor(en-US) = %q", got)
+	}
+}
+
+func TestCatalogFallback(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "zh-CN.yaml"), []byte("1008: \"没有权限\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// 缺少部分状态码的语言包回退到中文提示
+	if err := os.WriteFile(filepath.Join(dir, "ja-JP.yaml"), []byte("1006: \"ログインが必要です\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadCatalogs(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer resetCatalogs()
+
+	if got := CodeNeedLogin.MsgFor(Negotiate("ja")); got != "ログインが必要です" {
+		t.Errorf("MsgFor(ja) = %q", got)
+	}
+	if got := CodeForbidden.MsgFor("ja-JP"); got != "没有权限" {
+		t.Errorf("MsgFor(ja-JP) = %q, want zh-CN fallback", got)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "ja-JP.yaml"), []byte("abc: \"x\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadCatalogs(dir); err == nil {
+		t.Error("LoadCatalogs should reject non-numeric codes")
+	}
+
+	if err := LoadCatalogs(t.TempDir()); err == nil {
+		t.Error("LoadCatalogs should require the default locale catalog")
+	}
+}
+
+// TestCatalogsComplete 语言包需包含全部状态码 内置提示只有兜底的几条
+func TestCatalogsComplete(t *testing.T) {
+	for _, locale := range []string{DefaultLocale, "en-US"} {
+		m, err := loadCatalog(filepath.Join("../../conf/i18n", locale+".yaml"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for code := range codeStatusMap {
+			if m[code] == "" {
+				t.Errorf("%s.yaml: missing message for %d", locale, code)
+			}
+		}
+	}
+}
+
+func resetCatalogs() {
+	catalogs.Store(newCatalog(map[string]map[ResCode]string{DefaultLocale: fallbackMsgs}))
+}
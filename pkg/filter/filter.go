@@ -0,0 +1,177 @@
+/**
+ * @Author: LiuShuXin
+ * @Description: 列表接口的过滤参数 形如 ?filter=status:eq:active,created_at:gt:2025-01-01
+ * 多个条件之间为且的关系 in 的多个取值以 | 分隔 取值中不能包含逗号
+ * @File:  filter
+ * Software: Goland
+ * @Date: 2026/10/19 14:00
+ */
+
+package filter
+
+import (
+	"app-server/pkg/errcode"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Op 比较运算符
+type Op string
+
+const (
+	OpEq   Op = "eq"
+	OpNe   Op = "ne"
+	OpGt   Op = "gt"
+	OpGte  Op = "gte"
+	OpLt   Op = "lt"
+	OpLte  Op = "lte"
+	OpLike Op = "like" // 包含 仅字符串字段可用
+	OpIn   Op = "in"
+)
+
+// MaxInValues in 运算符最多允许的取值个数 避免生成占位符过多的查询
+const MaxInValues = 100
+
+// Type 字段类型 决定取值的解析方式及默认允许的运算符
+type Type int
+
+const (
+	String Type = iota
+	Int
+	Float
+	Bool
+	Time // 取值为 2006-01-02 或 RFC3339
+)
+
+var defaultOps = map[Type][]Op{
+	String: {OpEq, OpNe, OpLike, OpIn},
+	Int:    {OpEq, OpNe, OpGt, OpGte, OpLt, OpLte, OpIn},
+	Float:  {OpEq, OpNe, OpGt, OpGte, OpLt, OpLte},
+	Bool:   {OpEq, OpNe},
+	Time:   {OpEq, OpGt, OpGte, OpLt, OpLte},
+}
+
+// Field 允许过滤的字段
+type Field struct {
+	Column string // 列名/文档字段名
+	Type   Type
+	Ops    []Op // 允许的运算符 为空时按类型取默认值
+}
+
+// Schema 资源的过滤字段白名单 请求参数名 -> 字段定义
+type Schema map[string]Field
+
+// Condition 单个过滤条件 Value 已按字段类型解析 in 时为 []interface{}
+type Condition struct {
+	Field  string // 请求参数名
+	Column string
+	Op     Op
+	Value  interface{}
+}
+
+// Filter 解析后的过滤条件 各条件之间为且的关系
+type Filter []Condition
+
+// Parse 按 schema 解析过滤参数 未声明的字段、不允许的运算符或无法解析的取值返回 CodeInvalidParam
+func Parse(raw string, schema Schema) (Filter, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+	var f Filter
+	for _, expr := range strings.Split(raw, ",") {
+		parts := strings.SplitN(strings.TrimSpace(expr), ":", 3)
+		if len(parts) != 3 {
+			return nil, invalid("filter", errcode.Bilingual("%q 格式应为 字段:运算符:值", "%q must be field:op:value", expr))
+		}
+		name, op, value := parts[0], Op(strings.ToLower(parts[1])), parts[2]
+		field, ok := schema[name]
+		if !ok {
+			return nil, invalid(name, errcode.Bilingual("不支持按该字段过滤", "filtering on this field is not supported"))
+		}
+		if !field.allow(op) {
+			return nil, invalid(name, errcode.Bilingual("不支持运算符 %s", "operator %s is not supported", op))
+		}
+		v, m := field.parse(op, value)
+		if m != nil {
+			return nil, invalid(name, m)
+		}
+		f = append(f, Condition{Field: name, Column: field.Column, Op: op, Value: v})
+	}
+	return f, nil
+}
+
+// allow like 只对字符串字段生效 即使 Ops 中列出也不允许用于其他类型
+func (f Field) allow(op Op) bool {
+	if op == OpLike && f.Type != String {
+		return false
+	}
+	ops := f.Ops
+	if len(ops) == 0 {
+		ops = defaultOps[f.Type]
+	}
+	for _, o := range ops {
+		if o == op {
+			return true
+		}
+	}
+	return false
+}
+
+// parse 解析取值 失败时返回字段错误提示
+func (f Field) parse(op Op, s string) (interface{}, errcode.LocalizedMsg) {
+	if op != OpIn {
+		return f.parseValue(s)
+	}
+	values := strings.SplitN(s, "|", MaxInValues+1)
+	if len(values) > MaxInValues {
+		return nil, errcode.Bilingual("in 最多支持 %d 个取值", "in accepts at most %d values", MaxInValues)
+	}
+	list := make([]interface{}, 0, len(values))
+	for _, item := range values {
+		v, m := f.parseValue(item)
+		if m != nil {
+			return nil, m
+		}
+		list = append(list, v)
+	}
+	return list, nil
+}
+
+func (f Field) parseValue(s string) (interface{}, errcode.LocalizedMsg) {
+	switch f.Type {
+	case Int:
+		v, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, errcode.Bilingual("%q 不是整数", "%q is not an integer", s)
+		}
+		return v, nil
+	case Float:
+		v, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, errcode.Bilingual("%q 不是数字", "%q is not a number", s)
+		}
+		return v, nil
+	case Bool:
+		v, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, errcode.Bilingual("%q 不是布尔值", "%q is not a boolean", s)
+		}
+		return v, nil
+	case Time:
+		if t, err := time.ParseInLocation(time.DateOnly, s, time.Local); err == nil {
+			return t, nil
+		}
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return nil, errcode.Bilingual("%q 不是有效的时间", "%q is not a valid time", s)
+		}
+		return t, nil
+	}
+	return s, nil
+}
+
+func invalid(field string, m errcode.LocalizedMsg) error {
+	return errcode.New(errcode.CodeInvalidParam).WithLocalizedField(field, m)
+}
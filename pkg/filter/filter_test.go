@@ -0,0 +1,92 @@
+/**
+ * @Author: LiuShuXin
+ * @Description:
+ * @File:  filter_test
+ * Software: Goland
+ * @Date: 2026/10/19 15:00
+ */
+
+package filter
+
+import (
+	"app-server/pkg/errcode"
+	"strings"
+	"testing"
+	"time"
+)
+
+var userSchema = Schema{
+	"status":     {Column: "status", Type: String},
+	"age":        {Column: "age", Type: Int},
+	"created_at": {Column: "create_time", Type: Time},
+	"vip":        {Column: "is_vip", Type: Bool, Ops: []Op{OpEq}},
+}
+
+func TestParse(t *testing.T) {
+	f, err := Parse("status:eq:active, age:in:18|20 ,created_at:gt:2025-01-01T08:00:00Z", userSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(f) != 3 {
+		t.Fatalf("len = %d", len(f))
+	}
+	if f[0].Column != "status" || f[0].Op != OpEq || f[0].Value != "active" {
+		t.Errorf("f[0] = %+v", f[0])
+	}
+	if in := f[1].Value.([]interface{}); len(in) != 2 || in[1] != int64(20) {
+		t.Errorf("f[1] = %+v", f[1])
+	}
+	// 取值中的冒号保留
+	if ts, _ := f[2].Value.(time.Time); f[2].Column != "create_time" || !ts.Equal(time.Date(2025, 1, 1, 8, 0, 0, 0, time.UTC)) {
+		t.Errorf("f[2] = %+v", f[2])
+	}
+
+	if f, err = Parse("", userSchema); err != nil || f != nil {
+		t.Errorf("Parse(\"\") = %v, %v", f, err)
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	cases := []struct {
+		raw   string
+		field string
+	}{
+		{"status", "filter"},
+		{"password:eq:x", "password"},
+		{"status:gt:a", "status"},
+		{"age:eq:abc", "age"},
+		{"vip:ne:true", "vip"},
+		{"created_at:lt:yesterday", "created_at"},
+		{"age:in:" + strings.Repeat("1|", MaxInValues) + "1", "age"},
+	}
+	for _, c := range cases {
+		_, err := Parse(c.raw, userSchema)
+		ae, ok := errcode.From(err)
+		if !ok || ae.Code != errcode.CodeInvalidParam || ae.Details[c.field] == "" {
+			t.Errorf("Parse(%q) err = %v, want detail on %s", c.raw, err, c.field)
+		}
+	}
+}
+
+func TestParseLikeOnlyForString(t *testing.T) {
+	schema := Schema{"age": {Column: "age", Type: Int, Ops: []Op{OpEq, OpLike}}}
+	_, err := Parse("age:like:1", schema)
+	ae, ok := errcode.From(err)
+	if !ok || ae.Code != errcode.CodeInvalidParam || ae.Details["age"] == "" {
+		t.Fatalf("Parse(age:like:1) err = %v, want invalid param on age", err)
+	}
+	if _, err = Parse("age:eq:1", schema); err != nil {
+		t.Errorf("Parse(age:eq:1) err = %v", err)
+	}
+}
+
+func TestParseInvalidLocalized(t *testing.T) {
+	_, err := Parse("age:eq:abc", userSchema)
+	ae, _ := errcode.From(err)
+	if got := ae.DetailsFor("en-US")["age"]; got != `"abc" is not an integer` {
+		t.Errorf("en-US detail = %q", got)
+	}
+	if got := ae.DetailsFor(errcode.DefaultLocale)["age"]; got != `"abc" 不是整数` {
+		t.Errorf("zh-CN detail = %q", got)
+	}
+}
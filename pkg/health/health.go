@@ -0,0 +1,163 @@
+/**
+ * @Author: LiuShuXin
+ * @Description: 健康检查 各依赖注册检查函数 就绪探针据此判断服务能否接收流量
+ * @File:  health
+ * Software: Goland
+ * @Date: 2026/10/20 9:30
+ */
+
+package health
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	StatusUp           = "up"
+	StatusDown         = "down"
+	StatusShuttingDown = "shutting_down"
+
+	DefaultTimeout = 2 * time.Second
+)
+
+// ErrShuttingDown 服务正在停机
+var ErrShuttingDown = errors.New("server is shutting down")
+
+// Checker 检查函数 需在 ctx 超时前返回
+type Checker func(ctx context.Context) error
+
+type check struct {
+	name    string
+	timeout time.Duration
+	fn      Checker
+}
+
+// CheckResult 单项检查结果
+type CheckResult struct {
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+	Duration string `json:"duration,omitempty"`
+}
+
+// Report 整体检查结果
+type Report struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks,omitempty"`
+}
+
+// Healthy 是否全部通过
+func (r Report) Healthy() bool {
+	return r.Status == StatusUp
+}
+
+// Public 去掉错误详情及耗时的结果 供业务端口对外返回
+// 驱动返回的错误可能包含内网地址、端口等信息 不能直接暴露
+func (r Report) Public() Report {
+	if r.Checks == nil {
+		return r
+	}
+	checks := make(map[string]CheckResult, len(r.Checks))
+	for name, c := range r.Checks {
+		checks[name] = CheckResult{Status: c.Status}
+	}
+	return Report{Status: r.Status, Checks: checks}
+}
+
+var (
+	mu           sync.RWMutex
+	checks       []check
+	shuttingDown atomic.Bool
+)
+
+// Register 注册就绪检查 同名检查会被替换 timeout <= 0 时使用 DefaultTimeout
+func Register(name string, timeout time.Duration, fn Checker) {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	for i, c := range checks {
+		if c.name == name {
+			checks[i] = check{name: name, timeout: timeout, fn: fn}
+			return
+		}
+	}
+	checks = append(checks, check{name: name, timeout: timeout, fn: fn})
+}
+
+// SetShuttingDown 标记服务开始停机 之后就绪检查始终失败 使负载均衡尽快摘除流量
+func SetShuttingDown() {
+	shuttingDown.Store(true)
+}
+
+// ShuttingDown 服务是否正在停机
+func ShuttingDown() bool {
+	return shuttingDown.Load()
+}
+
+// Liveness 存活检查 只反映进程能否处理请求 不检查外部依赖 避免依赖故障时服务被反复重启
+func Liveness() Report {
+	return Report{Status: StatusUp}
+}
+
+// Readiness 并发执行全部就绪检查 任一失败或正在停机时不就绪
+func Readiness(ctx context.Context) Report {
+	if ShuttingDown() {
+		return Report{Status: StatusShuttingDown}
+	}
+	mu.RLock()
+	cs := make([]check, len(checks))
+	copy(cs, checks)
+	mu.RUnlock()
+
+	results := make([]CheckResult, len(cs))
+	var wg sync.WaitGroup
+	for i, c := range cs {
+		wg.Add(1)
+		go func(i int, c check) {
+			defer wg.Done()
+			results[i] = run(ctx, c)
+		}(i, c)
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusUp, Checks: make(map[string]CheckResult, len(cs))}
+	for i, c := range cs {
+		report.Checks[c.name] = results[i]
+		if results[i].Status != StatusUp {
+			report.Status = StatusDown
+		}
+	}
+	return report
+}
+
+func run(ctx context.Context, c check) (res CheckResult) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	start := time.Now()
+	defer func() { res.Duration = time.Since(start).String() }()
+
+	// 检查函数未遵守 ctx 超时时同样按超时处理
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- errors.New("panic during check")
+			}
+		}()
+		done <- c.fn(ctx)
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			return CheckResult{Status: StatusDown, Error: err.Error()}
+		}
+		return CheckResult{Status: StatusUp}
+	case <-ctx.Done():
+		return CheckResult{Status: StatusDown, Error: ctx.Err().Error()}
+	}
+}
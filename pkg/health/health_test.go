@@ -0,0 +1,84 @@
+/**
+ * @Author: LiuShuXin
+ * @Description:
+ * @File:  health_test
+ * Software: Goland
+ * @Date: 2026/10/20 10:10
+ */
+
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func reset() {
+	mu.Lock()
+	checks = nil
+	mu.Unlock()
+	shuttingDown.Store(false)
+}
+
+func TestReadiness(t *testing.T) {
+	reset()
+	defer reset()
+
+	if r := Readiness(context.Background()); !r.Healthy() {
+		t.Fatalf("no checks: %+v", r)
+	}
+
+	Register("mysql", 0, func(context.Context) error { return nil })
+	Register("redis", time.Second, func(context.Context) error { return errors.New("connection refused") })
+	// 不遵守 ctx 超时的检查
+	Register("slow", 20*time.Millisecond, func(context.Context) error {
+		time.Sleep(time.Second)
+		return nil
+	})
+
+	start := time.Now()
+	r := Readiness(context.Background())
+	if time.Since(start) > 500*time.Millisecond {
+		t.Errorf("Readiness() took %v, checks should time out", time.Since(start))
+	}
+	if r.Healthy() || len(r.Checks) != 3 {
+		t.Fatalf("report = %+v", r)
+	}
+	if c := r.Checks["mysql"]; c.Status != StatusUp {
+		t.Errorf("mysql = %+v", c)
+	}
+	if c := r.Checks["redis"]; c.Status != StatusDown || c.Error != "connection refused" {
+		t.Errorf("redis = %+v", c)
+	}
+	if c := r.Checks["slow"]; c.Status != StatusDown || c.Error != context.DeadlineExceeded.Error() {
+		t.Errorf("slow = %+v", c)
+	}
+
+	// 对外返回的结果不含错误详情
+	if c := r.Public().Checks["redis"]; c.Status != StatusDown || c.Error != "" || c.Duration != "" {
+		t.Errorf("public redis = %+v", c)
+	}
+
+	// 同名检查替换
+	Register("redis", 0, func(context.Context) error { return nil })
+	Register("slow", 0, func(context.Context) error { return nil })
+	if r = Readiness(context.Background()); !r.Healthy() || len(r.Checks) != 3 {
+		t.Fatalf("after replace: %+v", r)
+	}
+}
+
+func TestShuttingDown(t *testing.T) {
+	reset()
+	defer reset()
+
+	Register("mysql", 0, func(context.Context) error { return nil })
+	SetShuttingDown()
+	if r := Readiness(context.Background()); r.Healthy() || r.Status != StatusShuttingDown {
+		t.Fatalf("report = %+v", r)
+	}
+	if r := Liveness(); !r.Healthy() {
+		t.Fatalf("liveness = %+v", r)
+	}
+}
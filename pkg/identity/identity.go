@@ -0,0 +1,32 @@
+/**
+ * @Author: LiuShuXin
+ * @Description: 在 context.Context 中传递当前请求的身份信息 供 dao、日志等非 gin 代码使用
+ * @File:  identity
+ * Software: Goland
+ * @Date: 2026/10/18 18:30
+ */
+
+package identity
+
+import "context"
+
+// User 当前请求的调用方
+type User struct {
+	ID       int64    `json:"user_id,string"`
+	Username string   `json:"username"`
+	Roles    []string `json:"roles"`
+	Scopes   []string `json:"scopes,omitempty"`
+}
+
+type ctxKey struct{}
+
+// NewContext 返回携带身份信息的 context
+func NewContext(ctx context.Context, u User) context.Context {
+	return context.WithValue(ctx, ctxKey{}, u)
+}
+
+// FromContext 获取身份信息 未认证的请求返回 false
+func FromContext(ctx context.Context) (User, bool) {
+	u, ok := ctx.Value(ctxKey{}).(User)
+	return u, ok
+}
@@ -10,51 +10,239 @@ package jwt
 
 import (
 	"app-server/settings"
+	"crypto/ecdsa"
+	"crypto/x509"
+	"encoding/pem"
 	"errors"
-	"github.com/dgrijalva/jwt-go"
+	"fmt"
+	"os"
+	"strings"
 	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	TokenTypeAccess  = "access"
+	TokenTypeRefresh = "refresh"
+
+	defaultAccessExpire  = 15 * time.Minute
+	defaultRefreshExpire = 7 * 24 * time.Hour
+
+	minSecretLen            = 32                 // release 模式下 HS256 密钥的最小长度 字节
+	placeholderSecretPrefix = "please-change-me" // 配置文件中附带的示例密钥
 )
 
-var mySecret = []byte("app-server")
+var (
+	ErrInvalidToken     = errors.New("invalid token")
+	ErrTokenMalformed   = errors.New("token malformed")
+	ErrSignatureInvalid = errors.New("token signature invalid")
+	ErrTokenExpired     = errors.New("token expired")
+	ErrTokenNotValidYet = errors.New("token not valid yet")
+	ErrTokenType        = errors.New("unexpected token type")
+	ErrInvalidIssuer    = errors.New("invalid token issuer")
+	ErrInvalidAudience  = errors.New("invalid token audience")
+)
 
-// MyClaims 自定义声明结构体并内嵌 jwt.StandardClaims
-// jwt 包自带的 jwt.StandardClaims 只包含了官方字段
+var (
+	signingMethod jwt.SigningMethod
+	signKey       interface{} // 签名密钥 HS 为 []byte，RS/ES 为私钥
+	verifyKey     interface{} // 验签密钥 HS 为 []byte，RS/ES 为公钥
+	issuer        string
+	audience      string
+	accessExpire  time.Duration
+	refreshExpire time.Duration
+)
+
+// MyClaims 自定义声明结构体并内嵌 jwt.RegisteredClaims
+// jwt 包自带的 jwt.RegisteredClaims 只包含了官方字段
 // 我们这里需要额外记录一个 UserID 字段，所以要自定义结构体
 // 如果想要保存更多信息，都可以添加到这个结构体中
 type MyClaims struct {
-	UserID   int64  `json:"user_id"`
-	Username string `json:"username"`
-	jwt.StandardClaims
+	UserID    int64    `json:"user_id"`
+	Username  string   `json:"username"`
+	TokenType string   `json:"typ"`                 // access | refresh
+	Family    string   `json:"fam,omitempty"`       // refresh token 轮换链标识 同一次登录签发的 refresh token 共用
+	AuthTime  int64    `json:"auth_time,omitempty"` // 轮换链的首次签发（登录）时间 超过 refresh 有效期后不能再刷新
+	Roles     []string `json:"roles,omitempty"`
+	Scopes    []string `json:"scopes,omitempty"` // 直接授予的权限 一般为空，权限由角色决定
+	// RegisteredClaims.ID 即 jti 声明 吊销 token 时以此为键
+	jwt.RegisteredClaims
+}
+
+// Init 根据配置初始化签名算法与密钥
+// HS256 使用 jwt_secret，RS256 / ES256 使用 PEM 格式的密钥文件
+// release 模式下拒绝示例密钥及长度不足 32 字节的密钥
+func Init(cfg *settings.Auth, mode string) (err error) {
+	alg := cfg.JwtAlgorithm
+	if alg == "" {
+		alg = jwt.SigningMethodHS256.Alg()
+	}
+
+	switch alg {
+	case jwt.SigningMethodHS256.Alg():
+		if cfg.JwtSecret == "" {
+			return errors.New("jwt_secret is required for HS256")
+		}
+		if mode == "release" && (strings.HasPrefix(cfg.JwtSecret, placeholderSecretPrefix) || len(cfg.JwtSecret) < minSecretLen) {
+			return fmt.Errorf("jwt_secret must be a random string of at least %d bytes in release mode", minSecretLen)
+		}
+		signKey = []byte(cfg.JwtSecret)
+		verifyKey = signKey
+	case jwt.SigningMethodRS256.Alg():
+		var priv, pub []byte
+		if priv, pub, err = readKeyFiles(cfg); err != nil {
+			return
+		}
+		if signKey, err = jwt.ParseRSAPrivateKeyFromPEM(priv); err != nil {
+			return
+		}
+		if verifyKey, err = jwt.ParseRSAPublicKeyFromPEM(pub); err != nil {
+			return
+		}
+	case jwt.SigningMethodES256.Alg():
+		var priv, pub []byte
+		if priv, pub, err = readKeyFiles(cfg); err != nil {
+			return
+		}
+		if signKey, err = parseECPrivateKey(priv); err != nil {
+			return
+		}
+		if verifyKey, err = jwt.ParseECPublicKeyFromPEM(pub); err != nil {
+			return
+		}
+	default:
+		return fmt.Errorf("unsupported jwt algorithm: %s", alg)
+	}
+
+	signingMethod = jwt.GetSigningMethod(alg)
+	issuer = cfg.JwtIssuer
+	audience = cfg.JwtAudience
+	accessExpire = time.Duration(cfg.JwtAccessExpire) * time.Minute
+	if accessExpire <= 0 {
+		accessExpire = time.Duration(cfg.JwtExpire) * time.Hour
+	}
+	if accessExpire <= 0 {
+		accessExpire = defaultAccessExpire
+	}
+	refreshExpire = time.Duration(cfg.JwtRefreshExpire) * time.Hour
+	if refreshExpire <= 0 {
+		refreshExpire = defaultRefreshExpire
+	}
+	return
+}
+
+func readKeyFiles(cfg *settings.Auth) (priv, pub []byte, err error) {
+	if cfg.JwtPrivateKey == "" || cfg.JwtPublicKey == "" {
+		return nil, nil, fmt.Errorf("jwt_private_key and jwt_public_key are required for %s", cfg.JwtAlgorithm)
+	}
+	if priv, err = os.ReadFile(cfg.JwtPrivateKey); err != nil {
+		return
+	}
+	pub, err = os.ReadFile(cfg.JwtPublicKey)
+	return
+}
+
+// classify 将解析错误归类 便于调用方区分需要刷新 token 还是 token 被伪造
+// 签名错误优先于过期，避免对伪造的 token 提示过期
+func classify(err error) error {
+	switch {
+	case errors.Is(err, jwt.ErrTokenMalformed):
+		return fmt.Errorf("%w: %v", ErrTokenMalformed, err)
+	case errors.Is(err, jwt.ErrTokenSignatureInvalid), errors.Is(err, jwt.ErrTokenUnverifiable):
+		return fmt.Errorf("%w: %v", ErrSignatureInvalid, err)
+	case errors.Is(err, jwt.ErrTokenExpired):
+		return ErrTokenExpired
+	case errors.Is(err, jwt.ErrTokenNotValidYet):
+		return ErrTokenNotValidYet
+	case errors.Is(err, jwt.ErrTokenInvalidIssuer):
+		return ErrInvalidIssuer
+	case errors.Is(err, jwt.ErrTokenInvalidAudience):
+		return ErrInvalidAudience
+	}
+	return fmt.Errorf("%w: %v", ErrInvalidToken, err)
 }
 
-// GenToken 生成 JWT
-func GenToken(userID int64, username string) (string, error) {
+// parseECPrivateKey 兼容 SEC1（EC PRIVATE KEY）与 PKCS8（PRIVATE KEY）两种格式
+func parseECPrivateKey(data []byte) (*ecdsa.PrivateKey, error) {
+	if key, err := jwt.ParseECPrivateKeyFromPEM(data); err == nil {
+		return key, nil
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, jwt.ErrKeyMustBePEMEncoded
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	ecKey, ok := key.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, jwt.ErrNotECPrivateKey
+	}
+	return ecKey, nil
+}
+
+// GenToken 生成 access token
+func GenToken(userID int64, username string, roles ...string) (string, error) {
 	// 创建一个我们自己的声明的数据
 	c := MyClaims{
-		UserID:   userID,
-		Username: username, // 自定义字段
-		StandardClaims: jwt.StandardClaims{
-			ExpiresAt: time.Now().Add(time.Duration(settings.GetConf().JwtExpire) * time.Hour).Unix(), // 过期时间
-			Issuer:    "bluebell",                                                                     // 签发人
-		},
+		UserID:           userID,
+		Username:         username, // 自定义字段
+		TokenType:        TokenTypeAccess,
+		Roles:            roles,
+		RegisteredClaims: registeredClaims(newID(), time.Now().Add(accessExpire)),
+	}
+	return sign(c)
+}
+
+// registeredClaims jti 用于吊销 exp 为过期时间 iss、aud 分别为签发人及接收方
+func registeredClaims(jti string, expireAt time.Time) jwt.RegisteredClaims {
+	rc := jwt.RegisteredClaims{
+		ID:        jti,
+		ExpiresAt: jwt.NewNumericDate(expireAt),
+		Issuer:    issuer,
+	}
+	if audience != "" {
+		rc.Audience = jwt.ClaimStrings{audience}
 	}
+	return rc
+}
+
+func sign(c MyClaims) (string, error) {
 	// 使用指定的签名方法创建签名对象
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, c)
-	// 使用指定的 secret 签名并获得完整的编码后的字符串 token
-	return token.SignedString(mySecret)
+	token := jwt.NewWithClaims(signingMethod, c)
+	// 使用指定的密钥签名并获得完整的编码后的字符串 token
+	return token.SignedString(signKey)
 }
 
-// ParseToken 解析JWT
+// ParseToken 解析 access token
 func ParseToken(tokenString string) (*MyClaims, error) {
-	// 解析 token
-	token, err := jwt.ParseWithClaims(tokenString, &MyClaims{}, func(token *jwt.Token) (interface{}, error) {
-		return mySecret, nil
+	return parse(tokenString, TokenTypeAccess)
+}
+
+func parse(tokenString, tokenType string) (*MyClaims, error) {
+	// 只接受配置的签名算法 防止 alg 篡改（如 none 或用公钥做 HMAC 密钥）
+	opts := []jwt.ParserOption{jwt.WithValidMethods([]string{signingMethod.Alg()}), jwt.WithExpirationRequired()}
+	if issuer != "" {
+		opts = append(opts, jwt.WithIssuer(issuer))
+	}
+	if audience != "" {
+		opts = append(opts, jwt.WithAudience(audience))
+	}
+	// 解析 token 签名校验通过后才校验过期时间、签发人等声明
+	token, err := jwt.NewParser(opts...).ParseWithClaims(tokenString, &MyClaims{}, func(token *jwt.Token) (interface{}, error) {
+		return verifyKey, nil
 	})
 	if err != nil {
-		return nil, err
+		return nil, classify(err)
+	}
+	claims, ok := token.Claims.(*MyClaims)
+	if !ok || !token.Valid { // 校验 token
+		return nil, ErrInvalidToken
 	}
-	if claims, ok := token.Claims.(*MyClaims); ok && token.Valid { // 校验 token
-		return claims, nil
+	if claims.TokenType != tokenType {
+		return nil, ErrTokenType
 	}
-	return nil, errors.New("invalid token")
+	return claims, nil
 }
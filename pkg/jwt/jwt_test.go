@@ -0,0 +1,188 @@
+/**
+ * @Author: LiuShuXin
+ * @Description:
+ * @File:  jwt_test
+ * Software: Goland
+ * @Date: 2026/10/18 14:10
+ */
+
+package jwt
+
+import (
+	"app-server/settings"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func hsConfig() *settings.Auth {
+	return &settings.Auth{
+		JwtExpire:    1,
+		JwtSecret:    "test-secret",
+		JwtIssuer:    "report",
+		JwtAudience:  "report",
+		JwtAlgorithm: "HS256",
+	}
+}
+
+// writeKeys 将私钥、公钥以 PEM 格式写入临时目录
+func writeKeys(t *testing.T, priv any, pub any) (string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	privDER, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubDER, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privFile, pubFile := filepath.Join(dir, "private.pem"), filepath.Join(dir, "public.pem")
+	_ = os.WriteFile(privFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privDER}), 0600)
+	_ = os.WriteFile(pubFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}), 0600)
+	return privFile, pubFile
+}
+
+func TestGenAndParseToken(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	cases := map[string]func() *settings.Auth{
+		"HS256": hsConfig,
+		"RS256": func() *settings.Auth {
+			cfg := hsConfig()
+			cfg.JwtAlgorithm = "RS256"
+			cfg.JwtPrivateKey, cfg.JwtPublicKey = writeKeys(t, rsaKey, &rsaKey.PublicKey)
+			return cfg
+		},
+		"ES256": func() *settings.Auth {
+			cfg := hsConfig()
+			cfg.JwtAlgorithm = "ES256"
+			cfg.JwtPrivateKey, cfg.JwtPublicKey = writeKeys(t, ecKey, &ecKey.PublicKey)
+			return cfg
+		},
+	}
+	for name, cfg := range cases {
+		t.Run(name, func(t *testing.T) {
+			if err := Init(cfg(), "test"); err != nil {
+				t.Fatalf("Init() err: %v", err)
+			}
+			token, err := GenToken(1, "tom")
+			if err != nil {
+				t.Fatalf("GenToken() err: %v", err)
+			}
+			mc, err := ParseToken(token)
+			if err != nil {
+				t.Fatalf("ParseToken() err: %v", err)
+			}
+			if mc.UserID != 1 || mc.Username != "tom" || mc.Issuer != "report" {
+				t.Errorf("claims = %+v", mc)
+			}
+		})
+	}
+}
+
+func TestParseTokenRejectsUnexpectedAlg(t *testing.T) {
+	if err := Init(hsConfig(), "test"); err != nil {
+		t.Fatal(err)
+	}
+	claims := MyClaims{UserID: 1, RegisteredClaims: jwt.RegisteredClaims{
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)), Issuer: "report", Audience: jwt.ClaimStrings{"report"},
+	}}
+	for _, m := range []jwt.SigningMethod{jwt.SigningMethodHS512, jwt.SigningMethodNone} {
+		var key interface{} = []byte("test-secret")
+		if m == jwt.SigningMethodNone {
+			key = jwt.UnsafeAllowNoneSignatureType
+		}
+		token, err := jwt.NewWithClaims(m, claims).SignedString(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = ParseToken(token); err == nil {
+			t.Errorf("ParseToken() should reject alg %s", m.Alg())
+		}
+	}
+}
+
+func TestParseTokenVerifiesIssuerAndAudience(t *testing.T) {
+	other := hsConfig()
+	other.JwtIssuer, other.JwtAudience = "other", "other"
+	if err := Init(other, "test"); err != nil {
+		t.Fatal(err)
+	}
+	token, _ := GenToken(1, "tom")
+
+	if err := Init(hsConfig(), "test"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseToken(token); err != ErrInvalidIssuer {
+		t.Errorf("ParseToken() err = %v, want %v", err, ErrInvalidIssuer)
+	}
+}
+
+func TestInitRequiresSecret(t *testing.T) {
+	cfg := hsConfig()
+	cfg.JwtSecret = ""
+	if err := Init(cfg, "test"); err == nil {
+		t.Error("Init() should fail without secret")
+	}
+
+	// release 模式下拒绝示例密钥及过短的密钥
+	for _, secret := range []string{"please-change-me-to-a-long-random-string", "short-secret"} {
+		cfg.JwtSecret = secret
+		if err := Init(cfg, "release"); err == nil {
+			t.Errorf("Init(%q) should fail in release mode", secret)
+		}
+		if err := Init(cfg, "debug"); err != nil {
+			t.Errorf("Init(%q) in debug mode err: %v", secret, err)
+		}
+	}
+	cfg.JwtSecret = "0123456789abcdef0123456789abcdef"
+	if err := Init(cfg, "release"); err != nil {
+		t.Errorf("Init() with a 32 byte secret err: %v", err)
+	}
+}
+
+func TestParseTokenClassifiesErrors(t *testing.T) {
+	if err := Init(hsConfig(), "test"); err != nil {
+		t.Fatal(err)
+	}
+	sign := func(c MyClaims, key string) string {
+		token, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, c).SignedString([]byte(key))
+		return token
+	}
+	std := func(exp, nbf time.Duration) jwt.RegisteredClaims {
+		return jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(exp)),
+			NotBefore: jwt.NewNumericDate(time.Now().Add(nbf)),
+			Issuer:    "report",
+			Audience:  jwt.ClaimStrings{"report"},
+		}
+	}
+	cases := []struct {
+		name  string
+		token string
+		want  error
+	}{
+		{"malformed", "not-a-jwt", ErrTokenMalformed},
+		{"expired", sign(MyClaims{TokenType: TokenTypeAccess, RegisteredClaims: std(-time.Hour, -2*time.Hour)}, "test-secret"), ErrTokenExpired},
+		{"not valid yet", sign(MyClaims{TokenType: TokenTypeAccess, RegisteredClaims: std(2*time.Hour, time.Hour)}, "test-secret"), ErrTokenNotValidYet},
+		{"bad signature", sign(MyClaims{TokenType: TokenTypeAccess, RegisteredClaims: std(time.Hour, 0)}, "other-secret"), ErrSignatureInvalid},
+		{"expired and forged", sign(MyClaims{TokenType: TokenTypeAccess, RegisteredClaims: std(-time.Hour, -2*time.Hour)}, "other-secret"), ErrSignatureInvalid},
+	}
+	for _, c := range cases {
+		if _, err := ParseToken(c.token); !errors.Is(err, c.want) {
+			t.Errorf("%s: err = %v, want %v", c.name, err, c.want)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+/**
+ * @Author: LiuShuXin
+ * @Description: access + refresh 双 token 及 refresh token 轮换
+ * @File:  refresh
+ * Software: Goland
+ * @Date: 2026/10/18 14:40
+ */
+
+package jwt
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrTokenReused refresh token 已被使用过 可能已泄露 整条轮换链会被作废
+var ErrTokenReused = errors.New("refresh token reused")
+
+// TokenPair 登录 / 刷新后返回给客户端的 token
+type TokenPair struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"` // access token 有效期 s
+
+	RefreshExpiresIn int64 `json:"refresh_expires_in"` // refresh token 剩余有效期 s 到期后需重新登录
+}
+
+// RefreshStore 记录每条轮换链当前有效的 refresh token
+// 多实例部署时需使用共享存储（如 Redis）的实现
+type RefreshStore interface {
+	// Save 记录 family 当前有效的 jti
+	Save(ctx context.Context, family, jti string, ttl time.Duration) error
+	// Rotate 当 family 当前的 jti 等于 oldJTI 时替换为 newJTI 返回是否替换成功
+	Rotate(ctx context.Context, family, oldJTI, newJTI string, ttl time.Duration) (bool, error)
+	// Revoke 作废整条轮换链
+	Revoke(ctx context.Context, family string) error
+}
+
+var refreshStore RefreshStore = NewMemoryRefreshStore()
+
+// SetRefreshStore 替换 refresh token 存储 需在处理请求前调用
+func SetRefreshStore(s RefreshStore) {
+	refreshStore = s
+}
+
+// UserLoader 刷新 token 时按用户 ID 重新加载用户名及角色 用户已被禁用或删除时返回错误
+type UserLoader func(ctx context.Context, userID int64) (username string, roles []string, err error)
+
+// GenTokenPair 登录时签发 access token 与新轮换链上的第一个 refresh token
+func GenTokenPair(ctx context.Context, userID int64, username string, roles ...string) (*TokenPair, error) {
+	family, jti := newID(), newID()
+	if err := refreshStore.Save(ctx, family, jti, refreshExpire); err != nil {
+		return nil, err
+	}
+	return genPair(userID, username, roles, family, jti, time.Now().Unix())
+}
+
+// RefreshToken 使用 refresh token 换取新的 token 对 旧的 refresh token 随即失效
+// 已失效的 refresh token 再次使用视为泄露，整条轮换链作废，需重新登录
+// 角色通过 load 重新加载 不沿用旧 token 中的角色；轮换链自登录起超过 refresh 有效期后需重新登录
+func RefreshToken(ctx context.Context, tokenString string, load UserLoader) (*TokenPair, error) {
+	mc, err := parse(tokenString, TokenTypeRefresh)
+	if err != nil {
+		return nil, err
+	}
+	ttl := time.Until(time.Unix(mc.AuthTime, 0).Add(refreshExpire))
+	if mc.AuthTime == 0 || ttl <= 0 {
+		return nil, ErrTokenExpired
+	}
+	username, roles, err := load(ctx, mc.UserID)
+	if err != nil {
+		return nil, err
+	}
+	jti := newID()
+	ok, err := refreshStore.Rotate(ctx, mc.Family, mc.ID, jti, ttl)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		if err = refreshStore.Revoke(ctx, mc.Family); err != nil {
+			return nil, err
+		}
+		return nil, ErrTokenReused
+	}
+	return genPair(mc.UserID, username, roles, mc.Family, jti, mc.AuthTime)
+}
+
+// ParseRefreshToken 解析 refresh token
+func ParseRefreshToken(tokenString string) (*MyClaims, error) {
+	return parse(tokenString, TokenTypeRefresh)
+}
+
+// RevokeRefreshToken 作废 refresh token 所在的轮换链 用于退出登录
+func RevokeRefreshToken(ctx context.Context, tokenString string) error {
+	mc, err := parse(tokenString, TokenTypeRefresh)
+	if err != nil {
+		return err
+	}
+	return RevokeFamily(ctx, mc.Family)
+}
+
+// RevokeFamily 作废整条轮换链
+func RevokeFamily(ctx context.Context, family string) error {
+	return refreshStore.Revoke(ctx, family)
+}
+
+// genPair authTime 为轮换链的登录时间 refresh token 的过期时间不会超过 authTime + refresh 有效期
+func genPair(userID int64, username string, roles []string, family, jti string, authTime int64) (*TokenPair, error) {
+	access, err := GenToken(userID, username, roles...)
+	if err != nil {
+		return nil, err
+	}
+	refreshExpireAt := time.Unix(authTime, 0).Add(refreshExpire)
+	refresh, err := sign(MyClaims{
+		UserID:           userID,
+		Username:         username,
+		TokenType:        TokenTypeRefresh,
+		Family:           family,
+		AuthTime:         authTime,
+		Roles:            roles,
+		RegisteredClaims: registeredClaims(jti, refreshExpireAt),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &TokenPair{
+		AccessToken:  access,
+		RefreshToken: refresh,
+		ExpiresIn:    int64(accessExpire / time.Second),
+
+		RefreshExpiresIn: int64(time.Until(refreshExpireAt) / time.Second),
+	}, nil
+}
+
+// newID 生成随机 ID 用作 jti 与轮换链标识
+func newID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// MemoryRefreshStore 进程内存实现 仅适用于单实例部署与测试
+type MemoryRefreshStore struct {
+	mu        sync.Mutex
+	families  map[string]refreshEntry
+	lastPrune time.Time
+}
+
+type refreshEntry struct {
+	jti      string
+	expireAt time.Time
+}
+
+func NewMemoryRefreshStore() *MemoryRefreshStore {
+	return &MemoryRefreshStore{families: make(map[string]refreshEntry)}
+}
+
+func (s *MemoryRefreshStore) Save(_ context.Context, family, jti string, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.prune()
+	s.families[family] = refreshEntry{jti: jti, expireAt: time.Now().Add(ttl)}
+	return nil
+}
+
+func (s *MemoryRefreshStore) Rotate(_ context.Context, family, oldJTI, newJTI string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.families[family]
+	if !ok || e.jti != oldJTI || time.Now().After(e.expireAt) {
+		return false, nil
+	}
+	s.families[family] = refreshEntry{jti: newJTI, expireAt: time.Now().Add(ttl)}
+	return true, nil
+}
+
+func (s *MemoryRefreshStore) Revoke(_ context.Context, family string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.families, family)
+	return nil
+}
+
+// prune 清理已过期的轮换链 每分钟最多一次 调用方需持有锁
+func (s *MemoryRefreshStore) prune() {
+	now := time.Now()
+	if now.Sub(s.lastPrune) < time.Minute {
+		return
+	}
+	s.lastPrune = now
+	for k, e := range s.families {
+		if now.After(e.expireAt) {
+			delete(s.families, k)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+/**
+ * @Author: LiuShuXin
+ * @Description:
+ * @File:  refresh_test
+ * Software: Goland
+ * @Date: 2026/10/18 15:20
+ */
+
+package jwt
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// loadAs 模拟从用户存储重新加载的用户信息
+func loadAs(username string, roles ...string) UserLoader {
+	return func(context.Context, int64) (string, []string, error) {
+		return username, roles, nil
+	}
+}
+
+func TestRefreshTokenRotation(t *testing.T) {
+	if err := Init(hsConfig(), "test"); err != nil {
+		t.Fatal(err)
+	}
+	SetRefreshStore(NewMemoryRefreshStore())
+	ctx := context.Background()
+
+	pair, err := GenTokenPair(ctx, 1, "tom")
+	if err != nil {
+		t.Fatalf("GenTokenPair() err: %v", err)
+	}
+	if _, err = ParseToken(pair.RefreshToken); !errors.Is(err, ErrTokenType) {
+		t.Errorf("refresh token used as access token, err = %v", err)
+	}
+	if _, err = RefreshToken(ctx, pair.AccessToken, loadAs("tom")); !errors.Is(err, ErrTokenType) {
+		t.Errorf("access token used as refresh token, err = %v", err)
+	}
+
+	// 角色以重新加载的为准 不沿用旧 token 中的角色
+	next, err := RefreshToken(ctx, pair.RefreshToken, loadAs("tom", "user"))
+	if err != nil {
+		t.Fatalf("RefreshToken() err: %v", err)
+	}
+	if mc, err := ParseToken(next.AccessToken); err != nil || mc.UserID != 1 || len(mc.Roles) != 1 || mc.Roles[0] != "user" {
+		t.Errorf("ParseToken() = %+v, %v", mc, err)
+	}
+
+	// 旧 refresh token 再次使用 整条轮换链作废
+	if _, err = RefreshToken(ctx, pair.RefreshToken, loadAs("tom")); !errors.Is(err, ErrTokenReused) {
+		t.Errorf("reuse err = %v, want %v", err, ErrTokenReused)
+	}
+	if _, err = RefreshToken(ctx, next.RefreshToken, loadAs("tom")); !errors.Is(err, ErrTokenReused) {
+		t.Errorf("family should be revoked after reuse, err = %v", err)
+	}
+}
+
+func TestRevokeRefreshToken(t *testing.T) {
+	if err := Init(hsConfig(), "test"); err != nil {
+		t.Fatal(err)
+	}
+	SetRefreshStore(NewMemoryRefreshStore())
+	ctx := context.Background()
+
+	pair, _ := GenTokenPair(ctx, 1, "tom")
+	if err := RevokeRefreshToken(ctx, pair.RefreshToken); err != nil {
+		t.Fatalf("RevokeRefreshToken() err: %v", err)
+	}
+	if _, err := RefreshToken(ctx, pair.RefreshToken, loadAs("tom")); err == nil {
+		t.Error("RefreshToken() should fail after revoke")
+	}
+}
+
+func TestRefreshTokenReload(t *testing.T) {
+	if err := Init(hsConfig(), "test"); err != nil {
+		t.Fatal(err)
+	}
+	SetRefreshStore(NewMemoryRefreshStore())
+	ctx := context.Background()
+
+	// 用户被禁用或删除时拒绝刷新
+	errDisabled := errors.New("user disabled")
+	pair, _ := GenTokenPair(ctx, 1, "tom", "admin")
+	_, err := RefreshToken(ctx, pair.RefreshToken, func(context.Context, int64) (string, []string, error) {
+		return "", nil, errDisabled
+	})
+	if !errors.Is(err, errDisabled) {
+		t.Errorf("disabled user err = %v, want %v", err, errDisabled)
+	}
+}
+
+func TestRefreshTokenFamilyLifetime(t *testing.T) {
+	if err := Init(hsConfig(), "test"); err != nil {
+		t.Fatal(err)
+	}
+	SetRefreshStore(NewMemoryRefreshStore())
+	ctx := context.Background()
+
+	pair, _ := GenTokenPair(ctx, 1, "tom")
+	mc, err := parse(pair.RefreshToken, TokenTypeRefresh)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 轮换后的 refresh token 过期时间不会延长
+	next, err := RefreshToken(ctx, pair.RefreshToken, loadAs("tom"))
+	if err != nil {
+		t.Fatalf("RefreshToken() err: %v", err)
+	}
+	nmc, _ := parse(next.RefreshToken, TokenTypeRefresh)
+	if nmc.AuthTime != mc.AuthTime || !nmc.ExpiresAt.Equal(mc.ExpiresAt.Time) {
+		t.Errorf("rotated token auth_time/exp = %d/%v, want %d/%v", nmc.AuthTime, nmc.ExpiresAt, mc.AuthTime, mc.ExpiresAt)
+	}
+
+	// 登录时间早于 refresh 有效期的轮换链不能再刷新
+	old, _ := sign(MyClaims{
+		UserID:           1,
+		TokenType:        TokenTypeRefresh,
+		Family:           mc.Family,
+		AuthTime:         time.Now().Add(-refreshExpire - time.Minute).Unix(),
+		RegisteredClaims: registeredClaims(nmc.ID, time.Now().Add(time.Hour)),
+	})
+	if _, err = RefreshToken(ctx, old, loadAs("tom")); !errors.Is(err, ErrTokenExpired) {
+		t.Errorf("expired family err = %v, want %v", err, ErrTokenExpired)
+	}
+}
@@ -0,0 +1,93 @@
+/**
+ * @Author: LiuShuXin
+ * @Description: token 吊销 用于退出登录或 token 泄露后提前作废
+ * @File:  revoke
+ * Software: Goland
+ * @Date: 2026/10/18 15:50
+ */
+
+package jwt
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrTokenRevoked token 已被吊销
+var ErrTokenRevoked = errors.New("token revoked")
+
+// RevocationStore token 吊销名单 以 jti 为键，记录保留到 token 自然过期为止
+// 多实例部署时需使用共享存储（如 Redis）的实现
+type RevocationStore interface {
+	Revoke(ctx context.Context, jti string, ttl time.Duration) error
+	IsRevoked(ctx context.Context, jti string) (bool, error)
+}
+
+var revocationStore RevocationStore = NewMemoryRevocationStore()
+
+// SetRevocationStore 替换吊销名单存储 需在处理请求前调用
+func SetRevocationStore(s RevocationStore) {
+	revocationStore = s
+}
+
+// Revoke 吊销 token 直到其自然过期
+func Revoke(ctx context.Context, mc *MyClaims) error {
+	if mc.ID == "" || mc.ExpiresAt == nil {
+		return ErrInvalidToken
+	}
+	ttl := time.Until(mc.ExpiresAt.Time)
+	if ttl <= 0 {
+		return nil // 已过期 无需吊销
+	}
+	return revocationStore.Revoke(ctx, mc.ID, ttl)
+}
+
+// IsRevoked 判断 token 是否已被吊销
+func IsRevoked(ctx context.Context, mc *MyClaims) (bool, error) {
+	if mc.ID == "" {
+		return false, nil
+	}
+	return revocationStore.IsRevoked(ctx, mc.ID)
+}
+
+// MemoryRevocationStore 进程内存实现 仅适用于单实例部署与测试
+type MemoryRevocationStore struct {
+	mu        sync.Mutex
+	revoked   map[string]time.Time // jti -> 过期时间
+	lastPrune time.Time
+}
+
+func NewMemoryRevocationStore() *MemoryRevocationStore {
+	return &MemoryRevocationStore{revoked: make(map[string]time.Time)}
+}
+
+func (s *MemoryRevocationStore) Revoke(_ context.Context, jti string, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.prune()
+	s.revoked[jti] = time.Now().Add(ttl)
+	return nil
+}
+
+func (s *MemoryRevocationStore) IsRevoked(_ context.Context, jti string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	expireAt, ok := s.revoked[jti]
+	return ok && time.Now().Before(expireAt), nil
+}
+
+// prune 清理已过期的记录 每分钟最多一次 调用方需持有锁
+func (s *MemoryRevocationStore) prune() {
+	now := time.Now()
+	if now.Sub(s.lastPrune) < time.Minute {
+		return
+	}
+	s.lastPrune = now
+	for k, expireAt := range s.revoked {
+		if now.After(expireAt) {
+			delete(s.revoked, k)
+		}
+	}
+}
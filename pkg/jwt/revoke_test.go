@@ -0,0 +1,46 @@
+/**
+ * @Author: LiuShuXin
+ * @Description:
+ * @File:  revoke_test
+ * Software: Goland
+ * @Date: 2026/10/18 16:10
+ */
+
+package jwt
+
+import (
+	"context"
+	"testing"
+)
+
+func TestRevoke(t *testing.T) {
+	if err := Init(hsConfig(), "test"); err != nil {
+		t.Fatal(err)
+	}
+	SetRevocationStore(NewMemoryRevocationStore())
+	ctx := context.Background()
+
+	token, _ := GenToken(1, "tom")
+	mc, err := ParseToken(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mc.ID == "" {
+		t.Fatal("access token should carry jti")
+	}
+	if revoked, _ := IsRevoked(ctx, mc); revoked {
+		t.Fatal("token should not be revoked yet")
+	}
+	if err = Revoke(ctx, mc); err != nil {
+		t.Fatalf("Revoke() err: %v", err)
+	}
+	if revoked, _ := IsRevoked(ctx, mc); !revoked {
+		t.Error("token should be revoked")
+	}
+
+	other, _ := GenToken(1, "tom")
+	omc, _ := ParseToken(other)
+	if revoked, _ := IsRevoked(ctx, omc); revoked {
+		t.Error("other token of the same user should not be revoked")
+	}
+}
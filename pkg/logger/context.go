@@ -0,0 +1,60 @@
+/**
+ * @Author: LiuShuXin
+ * @Description: 从 context 中提取请求相关字段附加到日志
+ * @File:  context
+ * Software: Goland
+ * @Date: 2026/10/19 18:30
+ */
+
+package logger
+
+import (
+	"app-server/pkg/requestid"
+	"app-server/pkg/tracing"
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// ContextFields 请求相关的日志字段 请求 ID 及链路追踪的 trace_id、span_id
+func ContextFields(ctx context.Context) []zap.Field {
+	if ctx == nil {
+		return nil
+	}
+	var fields []zap.Field
+	if id := requestid.FromContext(ctx); id != "" {
+		fields = append(fields, zap.String("request_id", id))
+	}
+	if traceID, spanID := tracing.IDs(ctx); traceID != "" {
+		fields = append(fields, zap.String("trace_id", traceID), zap.String("span_id", spanID))
+	}
+	return fields
+}
+
+// WithContext 返回附带请求相关字段的全局日志 处理请求时使用 如：
+//
+//	logger.WithContext(c.Request.Context()).Error("query failed", zap.Error(err))
+//
+// zap.L() 及 DayLogger 的调用不携带 context 无法自动获取请求 ID
+// 因此请求链路上（controller、middlewares、logic、dao）的日志都需经由 WithContext 输出 直接使用 zap.L() 只限于启动、停机等与请求无关的场景
+func WithContext(ctx context.Context) *zap.Logger {
+	return zap.L().With(ContextFields(ctx)...)
+}
+
+// WithContext 返回附带请求相关字段的日志实例
+func (dl *DayLogger) WithContext(ctx context.Context) *DayLogger {
+	if ctx == nil {
+		return dl
+	}
+	fields := make(map[string]any)
+	if id := requestid.FromContext(ctx); id != "" {
+		fields["request_id"] = id
+	}
+	if traceID, spanID := tracing.IDs(ctx); traceID != "" {
+		fields["trace_id"], fields["span_id"] = traceID, spanID
+	}
+	if len(fields) == 0 {
+		return dl
+	}
+	return dl.WithFields(fields)
+}
@@ -0,0 +1,37 @@
+/**
+ * @Author: LiuShuXin
+ * @Description:
+ * @File:  context_test
+ * Software: Goland
+ * @Date: 2026/10/20 16:30
+ */
+
+package logger
+
+import (
+	"app-server/pkg/requestid"
+	"context"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestWithContext(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	defer zap.ReplaceGlobals(zap.New(core))()
+
+	WithContext(requestid.NewContext(context.Background(), "req-1")).Info("handled")
+	WithContext(context.Background()).Info("startup")
+
+	entries := logs.All()
+	if len(entries) != 2 {
+		t.Fatalf("entries = %d", len(entries))
+	}
+	if got := entries[0].ContextMap()["request_id"]; got != "req-1" {
+		t.Errorf("request_id = %v, want req-1", got)
+	}
+	if _, ok := entries[1].ContextMap()["request_id"]; ok {
+		t.Error("request_id should be absent without a request context")
+	}
+}
@@ -79,6 +79,14 @@ func Init(cfg *settings.LogConfig, mode string) (err error) {
 	return
 }
 
+// Sync 刷新全局日志缓冲 停机时调用
+func Sync() error {
+	if lg == nil {
+		return nil
+	}
+	return lg.Sync()
+}
+
 func getEncoder() zapcore.Encoder {
 	encoderConfig := zap.NewProductionEncoderConfig()
 	encoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
@@ -27,6 +27,7 @@ var (
 // DayLogger 自定义日志对象（每个实例对应一个日志文件）
 type DayLogger struct {
 	entry *logrus.Entry
+	file  *lumberjack.Logger
 }
 
 // NewDayLogger 创建独立日志实例
@@ -34,9 +35,11 @@ func NewDayLogger(logName string, age int) *DayLogger {
 	log := logrus.New()
 
 	// 设置日志级别
-	level, err := logrus.ParseLevel(settings.GetConf().Level)
-	if err != nil {
-		level = logrus.InfoLevel // 默认 info 级别
+	level := logrus.InfoLevel // 默认 info 级别
+	if cfg := settings.GetConf().LogConfig; cfg != nil {
+		if l, err := logrus.ParseLevel(cfg.Level); err == nil {
+			level = l
+		}
 	}
 	log.SetLevel(level)
 
@@ -63,6 +66,7 @@ func NewDayLogger(logName string, age int) *DayLogger {
 
 	return &DayLogger{
 		entry: log.WithFields(logrus.Fields{}), // 初始无额外字段
+		file:  logFile,
 	}
 }
 
@@ -85,15 +89,23 @@ func (dl *DayLogger) Errorf(format string, args ...any) {
 
 // WithField 添加字段（返回新实例）
 func (dl *DayLogger) WithField(key string, value any) *DayLogger {
-	return &DayLogger{entry: dl.entry.WithField(key, value)}
+	return &DayLogger{entry: dl.entry.WithField(key, value), file: dl.file}
 }
 
 // WithFields 添加多个字段（返回新实例）
 func (dl *DayLogger) WithFields(fields map[string]any) *DayLogger {
-	return &DayLogger{entry: dl.entry.WithFields(fields)}
+	return &DayLogger{entry: dl.entry.WithFields(fields), file: dl.file}
 }
 
 // GetWriter 获取 io.Writer（用于 Gin 等框架的默认日志输出）
 func (dl *DayLogger) GetWriter() io.Writer {
 	return dl.entry.Writer()
 }
+
+// Close 关闭日志文件 停机时调用
+func (dl *DayLogger) Close() error {
+	if dl.file == nil {
+		return nil
+	}
+	return dl.file.Close()
+}
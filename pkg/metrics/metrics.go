@@ -0,0 +1,200 @@
+/**
+ * @Author: LiuShuXin
+ * @Description: Prometheus 指标 包括 HTTP 请求的 RED 指标、Go 运行时指标及业务自定义指标
+ * @File:  metrics
+ * Software: Goland
+ * @Date: 2026/10/19 22:00
+ */
+
+package metrics
+
+import (
+	"app-server/settings"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	DefaultPath = "/metrics"
+	otherMethod = "OTHER"
+)
+
+// knownMethods net/http 接受任意 token 作为请求方法 只有标准方法单独作为标签值
+var knownMethods = map[string]struct{}{
+	http.MethodGet: {}, http.MethodHead: {}, http.MethodPost: {}, http.MethodPut: {}, http.MethodPatch: {},
+	http.MethodDelete: {}, http.MethodConnect: {}, http.MethodOptions: {}, http.MethodTrace: {},
+}
+
+type httpMetrics struct {
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	inFlight *prometheus.GaugeVec
+}
+
+var (
+	registry  = prometheus.NewRegistry()
+	namespace string
+	path      = DefaultPath
+	enabled   atomic.Bool
+	red       *httpMetrics
+)
+
+// Init 注册运行时指标及 HTTP 请求指标 未开启时中间件与指标路由均不生效
+func Init(cfg *settings.Metrics, serviceName string) error {
+	if cfg == nil || !cfg.Enable {
+		return nil
+	}
+	namespace = sanitize(cfg.Namespace)
+	if namespace == "" {
+		namespace = sanitize(serviceName)
+	}
+	if cfg.Path != "" {
+		path = cfg.Path
+	}
+	buckets := cfg.Buckets
+	if len(buckets) == 0 {
+		buckets = prometheus.DefBuckets
+	}
+
+	red = &httpMetrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "Total number of HTTP requests.",
+		}, []string{"method", "route", "status"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "HTTP request latency in seconds.",
+			Buckets:   buckets,
+		}, []string{"method", "route", "status"}),
+		inFlight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "requests_in_flight",
+			Help:      "Number of HTTP requests currently being served.",
+		}, []string{"method", "route"}),
+	}
+	for _, c := range []prometheus.Collector{
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		red.requests, red.duration, red.inFlight,
+	} {
+		if err := registry.Register(c); err != nil {
+			return err
+		}
+	}
+	enabled.Store(true)
+	return nil
+}
+
+// Enabled 是否开启指标
+func Enabled() bool {
+	return enabled.Load()
+}
+
+// Namespace 指标名前缀 供自定义采集器使用
+func Namespace() string {
+	return namespace
+}
+
+// Path 指标路由
+func Path() string {
+	return path
+}
+
+// Handler 输出指标的 HTTP 处理器
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{Registry: registry})
+}
+
+// StartRequest 记录请求开始 返回的函数在请求结束时调用
+// route 为路由模板 如 /api/v1/user/:id 不能使用实际路径 否则指标数量会无限增长
+// method 同理 非标准的请求方法统一记为 OTHER
+func StartRequest(method, route string) func(status int) {
+	if !enabled.Load() {
+		return func(int) {}
+	}
+	if _, ok := knownMethods[method]; !ok {
+		method = otherMethod
+	}
+	start := time.Now()
+	g := red.inFlight.WithLabelValues(method, route)
+	g.Inc()
+	return func(status int) {
+		g.Dec()
+		code := strconv.Itoa(status)
+		red.requests.WithLabelValues(method, route, code).Inc()
+		red.duration.WithLabelValues(method, route, code).Observe(time.Since(start).Seconds())
+	}
+}
+
+// Register 注册自定义采集器 如连接池状态 重复注册时忽略
+func Register(c prometheus.Collector) error {
+	err := registry.Register(c)
+	var are prometheus.AlreadyRegisteredError
+	if errors.As(err, &are) {
+		return nil
+	}
+	return err
+}
+
+// NewCounter 创建业务计数器 指标名自动加上命名空间前缀 同名计数器重复创建时返回已注册的实例
+// 需在 Init 之后调用 不能用于包级变量的初始化（此时命名空间尚未设置）如在业务模块初始化时：
+//
+//	var orders *prometheus.CounterVec
+//
+//	func InitMetrics() {
+//		orders = metrics.NewCounter("orders_created_total", "Orders created.", "channel")
+//	}
+//
+//	orders.WithLabelValues("app").Inc()
+func NewCounter(name, help string, labels ...string) *prometheus.CounterVec {
+	c := prometheus.NewCounterVec(prometheus.CounterOpts{Namespace: namespace, Name: name, Help: help}, labels)
+	return register(c)
+}
+
+// NewGauge 创建业务仪表盘指标 用法同 NewCounter
+func NewGauge(name, help string, labels ...string) *prometheus.GaugeVec {
+	g := prometheus.NewGaugeVec(prometheus.GaugeOpts{Namespace: namespace, Name: name, Help: help}, labels)
+	return register(g)
+}
+
+// NewHistogram 创建业务直方图 buckets 为空时使用默认分桶
+func NewHistogram(name, help string, buckets []float64, labels ...string) *prometheus.HistogramVec {
+	h := prometheus.NewHistogramVec(prometheus.HistogramOpts{Namespace: namespace, Name: name, Help: help, Buckets: buckets}, labels)
+	return register(h)
+}
+
+func register[T prometheus.Collector](c T) T {
+	if err := registry.Register(c); err != nil {
+		var are prometheus.AlreadyRegisteredError
+		if errors.As(err, &are) {
+			if existing, ok := are.ExistingCollector.(T); ok {
+				return existing
+			}
+		}
+		panic(err)
+	}
+	return c
+}
+
+// sanitize 服务名转为合法的指标名前缀 非法字符替换为下划线
+func sanitize(name string) string {
+	b := []byte(name)
+	for i, ch := range b {
+		if !(ch == '_' || ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z' || i > 0 && ch >= '0' && ch <= '9') {
+			b[i] = '_'
+		}
+	}
+	return string(b)
+}
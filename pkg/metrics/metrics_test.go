@@ -0,0 +1,68 @@
+/**
+ * @Author: LiuShuXin
+ * @Description:
+ * @File:  metrics_test
+ * Software: Goland
+ * @Date: 2026/10/19 23:00
+ */
+
+package metrics
+
+import (
+	"app-server/settings"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func scrape(t *testing.T) string {
+	t.Helper()
+	w := httptest.NewRecorder()
+	Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, DefaultPath, nil))
+	body, _ := io.ReadAll(w.Body)
+	return string(body)
+}
+
+func TestMetrics(t *testing.T) {
+	done := StartRequest(http.MethodGet, "/noop")
+	done(http.StatusOK) // 未开启时不记录
+
+	if err := Init(&settings.Metrics{Enable: true}, "report-server"); err != nil {
+		t.Fatal(err)
+	}
+	if !Enabled() || Namespace() != "report_server" || Path() != DefaultPath {
+		t.Fatalf("enabled=%v namespace=%q path=%q", Enabled(), Namespace(), Path())
+	}
+
+	done = StartRequest(http.MethodGet, "/api/v1/user/:id")
+	if !strings.Contains(scrape(t), `report_server_http_requests_in_flight{method="GET",route="/api/v1/user/:id"} 1`) {
+		t.Error("in-flight gauge not incremented")
+	}
+	done(http.StatusNotFound)
+	StartRequest("SCANNER123", "unmatched")(http.StatusNotFound)
+
+	orders := NewCounter("orders_created_total", "Orders created.", "channel")
+	if again := NewCounter("orders_created_total", "Orders created.", "channel"); again != orders {
+		t.Error("NewCounter should return the registered counter")
+	}
+	orders.WithLabelValues("app").Add(2)
+
+	body := scrape(t)
+	for _, want := range []string{
+		`report_server_http_requests_total{method="GET",route="/api/v1/user/:id",status="404"} 1`,
+		`report_server_http_requests_in_flight{method="GET",route="/api/v1/user/:id"} 0`,
+		`report_server_http_request_duration_seconds_count{method="GET",route="/api/v1/user/:id",status="404"} 1`,
+		`report_server_orders_created_total{channel="app"} 2`,
+		`report_server_http_requests_total{method="OTHER",route="unmatched",status="404"} 1`,
+		`go_goroutines`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("metrics missing %s", want)
+		}
+	}
+	if strings.Contains(body, "/noop") {
+		t.Error("requests before Init should not be recorded")
+	}
+}
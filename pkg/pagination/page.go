@@ -0,0 +1,52 @@
+/**
+ * @Author: LiuShuXin
+ * @Description: 分页响应
+ * @File:  page
+ * Software: Goland
+ * @Date: 2026/10/19 11:20
+ */
+
+package pagination
+
+import "strconv"
+
+// Page 分页响应 作为 ResponseData.Data 返回
+// 游标分页不统计总数 Total 为 -1
+type Page[T any] struct {
+	List       []T    `json:"list"`
+	Total      int64  `json:"total"`
+	Page       int    `json:"page,omitempty"`
+	PageSize   int    `json:"page_size"`
+	NextCursor string `json:"next_cursor,omitempty"` // 雪花 ID 超出 js 安全整数范围 以字符串返回
+	HasMore    bool   `json:"has_more"`
+}
+
+// NewOffsetPage 偏移分页响应
+func NewOffsetPage[T any](list []T, total int64, p Params) *Page[T] {
+	if list == nil {
+		list = []T{}
+	}
+	return &Page[T]{
+		List:     list,
+		Total:    total,
+		Page:     p.Page,
+		PageSize: p.PageSize,
+		HasMore:  int64(p.Offset()+len(list)) < total,
+	}
+}
+
+// NewCursorPage 游标分页响应 list 为按 Params.Limit 查询到的结果 id 返回记录的雪花 ID
+func NewCursorPage[T any](list []T, p Params, id func(T) int64) *Page[T] {
+	page := &Page[T]{Total: -1, PageSize: p.PageSize}
+	if len(list) > p.PageSize {
+		list, page.HasMore = list[:p.PageSize], true
+	}
+	if list == nil {
+		list = []T{}
+	}
+	page.List = list
+	if page.HasMore {
+		page.NextCursor = strconv.FormatInt(id(list[len(list)-1]), 10)
+	}
+	return page
+}
@@ -0,0 +1,174 @@
+/**
+ * @Author: LiuShuXin
+ * @Description: 列表接口的分页与排序 支持偏移分页与基于雪花 ID 的游标分页
+ * @File:  pagination
+ * Software: Goland
+ * @Date: 2026/10/19 11:00
+ */
+
+package pagination
+
+import (
+	"app-server/models"
+	"app-server/pkg/errcode"
+	"app-server/settings"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+const (
+	DefaultPageSize = 10
+	MaxPageSize     = 100
+
+	maxOffset = math.MaxInt32 // 偏移分页的最大偏移量 页码过大时拒绝 避免计算偏移量溢出
+)
+
+// ErrNoCursorField 游标分页未指定游标字段 属于接口定义错误而非请求参数错误
+var ErrNoCursorField = errors.New("pagination: cursor mode requires CursorField")
+
+// Mode 分页方式
+type Mode int
+
+const (
+	// ModeOffset 按页码分页 适合需要跳页及总数的管理后台
+	ModeOffset Mode = iota
+	// ModeCursor 按雪花 ID 游标分页 翻页深度不影响性能 适合信息流类接口
+	ModeCursor
+)
+
+const (
+	OrderAsc  = "asc"
+	OrderDesc = "desc"
+)
+
+type limits struct {
+	defaultSize int
+	maxSize     int
+}
+
+var conf atomic.Pointer[limits]
+
+func init() {
+	conf.Store(&limits{defaultSize: DefaultPageSize, maxSize: MaxPageSize})
+}
+
+// Init 设置默认每页条数及上限 未配置的项使用包内默认值
+func Init(cfg *settings.Pagination) {
+	l := &limits{defaultSize: DefaultPageSize, maxSize: MaxPageSize}
+	if cfg != nil {
+		if cfg.MaxPageSize > 0 {
+			l.maxSize = cfg.MaxPageSize
+		}
+		if cfg.DefaultPageSize > 0 {
+			l.defaultSize = cfg.DefaultPageSize
+		}
+	}
+	if l.defaultSize > l.maxSize {
+		l.defaultSize = l.maxSize
+	}
+	conf.Store(l)
+}
+
+// Options 接口的分页规则
+type Options struct {
+	Mode Mode
+	// Sortable 允许排序的字段 请求参数名 -> 列名/文档字段名 未列出的字段拒绝排序
+	Sortable map[string]string
+	// DefaultSort 未传 sort 时的排序字段 取 Sortable 中的请求参数名
+	DefaultSort string
+	// DefaultOrder 未传 order 时的排序方向 默认 desc
+	DefaultOrder string
+	// CursorField 游标分页使用的雪花 ID 列名 游标分页时只能按该列排序
+	CursorField string
+}
+
+// Params 校验并规范化后的分页参数 由 DAO 层据此拼接查询
+type Params struct {
+	Mode     Mode
+	Page     int
+	PageSize int
+	Sort     string // 列名/文档字段名
+	Desc     bool
+	Cursor   int64 // 游标分页时上一页最后一条记录的 ID 0 表示第一页
+}
+
+// Offset 偏移分页的起始位置
+func (p Params) Offset() int {
+	return (p.Page - 1) * p.PageSize
+}
+
+// Limit 查询条数 游标分页多取一条用于判断是否还有下一页
+func (p Params) Limit() int {
+	if p.Mode == ModeCursor {
+		return p.PageSize + 1
+	}
+	return p.PageSize
+}
+
+// Parse 校验请求中的分页参数 非法的排序字段、方向或游标返回 CodeInvalidParam
+func Parse(req models.Pagination, opt Options) (Params, error) {
+	l := conf.Load()
+	p := Params{Mode: opt.Mode, Page: req.PageNum, PageSize: req.PageSize}
+	if p.Page < 1 {
+		p.Page = 1
+	}
+	if p.PageSize < 1 {
+		p.PageSize = l.defaultSize
+	}
+	if p.PageSize > l.maxSize {
+		p.PageSize = l.maxSize
+	}
+	if p.Page-1 > maxOffset/p.PageSize {
+		return Params{}, invalidParam("pagenum", errcode.Bilingual("pagenum 超出范围", "pagenum is out of range"))
+	}
+
+	order := strings.ToLower(req.Order)
+	if order == "" {
+		order = strings.ToLower(opt.DefaultOrder)
+	}
+	switch order {
+	case "", OrderDesc:
+		p.Desc = true
+	case OrderAsc:
+	default:
+		return Params{}, invalidParam("order", errcode.Bilingual("order 只能为 asc 或 desc", "order must be asc or desc"))
+	}
+
+	if opt.Mode == ModeCursor {
+		if opt.CursorField == "" {
+			return Params{}, ErrNoCursorField
+		}
+		if req.Sort != "" && opt.Sortable[req.Sort] != opt.CursorField {
+			return Params{}, invalidParam("sort", errcode.Bilingual("游标分页不支持指定排序字段", "sort is not supported in cursor pagination"))
+		}
+		p.Sort, p.Page = opt.CursorField, 1
+		if req.Cursor != "" {
+			id, err := strconv.ParseInt(req.Cursor, 10, 64)
+			if err != nil || id <= 0 {
+				return Params{}, invalidParam("cursor", errcode.Bilingual("无效的游标", "invalid cursor"))
+			}
+			p.Cursor = id
+		}
+		return p, nil
+	}
+
+	sort := req.Sort
+	if sort == "" {
+		sort = opt.DefaultSort
+	}
+	if sort != "" {
+		column, ok := opt.Sortable[sort]
+		if !ok {
+			return Params{}, invalidParam("sort", errcode.Bilingual("不支持按 %s 排序", "sorting by %s is not supported", sort))
+		}
+		p.Sort = column
+	}
+	return p, nil
+}
+
+func invalidParam(field string, m errcode.LocalizedMsg) error {
+	return errcode.New(errcode.CodeInvalidParam).WithLocalizedField(field, m)
+}
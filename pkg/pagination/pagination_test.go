@@ -0,0 +1,115 @@
+/**
+ * @Author: LiuShuXin
+ * @Description:
+ * @File:  pagination_test
+ * Software: Goland
+ * @Date: 2026/10/19 12:00
+ */
+
+package pagination
+
+import (
+	"app-server/models"
+	"app-server/pkg/errcode"
+	"app-server/settings"
+	"errors"
+	"math"
+	"testing"
+)
+
+var userOptions = Options{
+	Sortable:    map[string]string{"id": "user_id", "created_at": "create_time"},
+	DefaultSort: "created_at",
+	CursorField: "user_id",
+}
+
+func TestParseOffset(t *testing.T) {
+	Init(&settings.Pagination{DefaultPageSize: 20, MaxPageSize: 50})
+	defer Init(nil)
+
+	p, err := Parse(models.Pagination{}, userOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Page != 1 || p.PageSize != 20 || p.Sort != "create_time" || !p.Desc {
+		t.Fatalf("defaults: %+v", p)
+	}
+
+	p, err = Parse(models.Pagination{PageNum: 3, PageSize: 1000, Sort: "id", Order: "ASC"}, userOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.PageSize != 50 || p.Offset() != 100 || p.Sort != "user_id" || p.Desc {
+		t.Fatalf("custom: %+v", p)
+	}
+
+	for _, req := range []models.Pagination{
+		{Sort: "password"},
+		{Order: "random"},
+		{PageNum: math.MaxInt},
+	} {
+		_, err = Parse(req, userOptions)
+		if !errors.Is(err, errcode.New(errcode.CodeInvalidParam)) {
+			t.Errorf("Parse(%+v) err = %v, want CodeInvalidParam", req, err)
+		}
+	}
+}
+
+func TestParsePageNumOverflow(t *testing.T) {
+	_, err := Parse(models.Pagination{PageNum: math.MaxInt, PageSize: 10}, userOptions)
+	ae, ok := errcode.From(err)
+	if !ok || ae.Details["pagenum"] == "" {
+		t.Fatalf("err = %v, want invalid pagenum", err)
+	}
+	if got := ae.DetailsFor("en-US")["pagenum"]; got != "pagenum is out of range" {
+		t.Errorf("en-US detail = %q", got)
+	}
+	p, err := Parse(models.Pagination{PageNum: 1000, PageSize: 10}, userOptions)
+	if err != nil || p.Offset() != 9990 {
+		t.Errorf("Parse(pagenum=1000) = %+v, %v", p, err)
+	}
+}
+
+func TestParseCursor(t *testing.T) {
+	opt := userOptions
+	opt.Mode = ModeCursor
+
+	p, err := Parse(models.Pagination{Cursor: "1234567890123456789", PageSize: 5}, opt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Cursor != 1234567890123456789 || p.Sort != "user_id" || p.Limit() != 6 {
+		t.Fatalf("cursor: %+v", p)
+	}
+	if _, err = Parse(models.Pagination{Cursor: "abc"}, opt); err == nil {
+		t.Error("invalid cursor should be rejected")
+	}
+	if _, err = Parse(models.Pagination{Sort: "created_at"}, opt); err == nil {
+		t.Error("sorting by non-cursor field should be rejected")
+	}
+	opt.CursorField = ""
+	if _, err = Parse(models.Pagination{}, opt); !errors.Is(err, ErrNoCursorField) {
+		t.Errorf("missing cursor field err = %v, want %v", err, ErrNoCursorField)
+	}
+}
+
+func TestPage(t *testing.T) {
+	p := Params{Mode: ModeOffset, Page: 2, PageSize: 2}
+	page := NewOffsetPage([]int64{3, 4}, 5, p)
+	if !page.HasMore || page.Total != 5 {
+		t.Fatalf("offset page: %+v", page)
+	}
+	if page = NewOffsetPage[int64](nil, 0, p); page.List == nil || page.HasMore {
+		t.Fatalf("empty offset page: %+v", page)
+	}
+
+	p = Params{Mode: ModeCursor, PageSize: 2}
+	id := func(v int64) int64 { return v }
+	page = NewCursorPage([]int64{9, 8, 7}, p, id)
+	if !page.HasMore || len(page.List) != 2 || page.NextCursor != "8" || page.Total != -1 {
+		t.Fatalf("cursor page: %+v", page)
+	}
+	if page = NewCursorPage([]int64{9}, p, id); page.HasMore || page.NextCursor != "" {
+		t.Fatalf("last cursor page: %+v", page)
+	}
+}
@@ -0,0 +1,149 @@
+/**
+ * @Author: LiuShuXin
+ * @Description: 基于角色的权限控制 角色与权限的对应关系定义在 conf/policy.yaml
+ * @File:  rbac
+ * Software: Goland
+ * @Date: 2026/10/18 17:30
+ */
+
+package rbac
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+)
+
+// Policy 权限策略
+type Policy struct {
+	Roles map[string]Role `mapstructure:"roles"`
+}
+
+// Role 角色定义
+type Role struct {
+	Inherits    []string `mapstructure:"inherits"`    // 继承的角色
+	Permissions []string `mapstructure:"permissions"` // 权限 形如 resource:action，支持 resource:* 与 *
+}
+
+// 展开继承关系后的 角色 -> 权限集合
+var perms atomic.Pointer[map[string]map[string]struct{}]
+
+// Init 从文件加载权限策略 文件变更时自动重新加载
+func Init(path string) (err error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err = v.ReadInConfig(); err != nil {
+		return
+	}
+	if err = load(v); err != nil {
+		return
+	}
+
+	v.WatchConfig()
+	v.OnConfigChange(func(in fsnotify.Event) {
+		if err := load(v); err != nil {
+			fmt.Printf("reload policy failed, err:%v\n", err)
+		}
+	})
+	return
+}
+
+func load(v *viper.Viper) error {
+	var p Policy
+	if err := v.Unmarshal(&p); err != nil {
+		return err
+	}
+	return Load(p)
+}
+
+// Load 加载权限策略 展开角色继承关系
+func Load(p Policy) error {
+	m := make(map[string]map[string]struct{}, len(p.Roles))
+	for name := range p.Roles {
+		set := make(map[string]struct{})
+		if err := expand(p, name, set, map[string]bool{}); err != nil {
+			return err
+		}
+		m[name] = set
+	}
+	perms.Store(&m)
+	return nil
+}
+
+func expand(p Policy, name string, set map[string]struct{}, visiting map[string]bool) error {
+	if visiting[name] {
+		return fmt.Errorf("rbac: role %s has circular inherits", name)
+	}
+	role, ok := p.Roles[name]
+	if !ok {
+		return fmt.Errorf("rbac: role %s is not defined", name)
+	}
+	visiting[name] = true
+	defer delete(visiting, name)
+	for _, perm := range role.Permissions {
+		set[perm] = struct{}{}
+	}
+	for _, parent := range role.Inherits {
+		if err := expand(p, parent, set, visiting); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// HasAnyRole 判断 roles 中是否包含 want 中的任一角色
+func HasAnyRole(roles []string, want ...string) bool {
+	for _, r := range roles {
+		for _, w := range want {
+			if r == w {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Allowed 判断 roles 是否拥有权限 perm
+func Allowed(roles []string, perm string) bool {
+	m := perms.Load()
+	if m == nil {
+		return false
+	}
+	for _, r := range roles {
+		if match((*m)[r], perm) {
+			return true
+		}
+	}
+	return false
+}
+
+// Granted 判断直接授予的权限列表是否覆盖 perm
+func Granted(granted []string, perm string) bool {
+	if len(granted) == 0 {
+		return false
+	}
+	set := make(map[string]struct{}, len(granted))
+	for _, g := range granted {
+		set[g] = struct{}{}
+	}
+	return match(set, perm)
+}
+
+// match 判断权限集合是否覆盖 perm
+func match(granted map[string]struct{}, perm string) bool {
+	if _, ok := granted["*"]; ok {
+		return true
+	}
+	if _, ok := granted[perm]; ok {
+		return true
+	}
+	if i := strings.IndexByte(perm, ':'); i > 0 {
+		if _, ok := granted[perm[:i]+":*"]; ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,75 @@
+/**
+ * @Author: LiuShuXin
+ * @Description:
+ * @File:  rbac_test
+ * Software: Goland
+ * @Date: 2026/10/18 18:10
+ */
+
+package rbac
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const policyYAML = `
+roles:
+  user:
+    permissions: ["user:read"]
+  editor:
+    inherits: [user]
+    permissions: ["article:*"]
+  admin:
+    permissions: ["*"]
+`
+
+func TestAllowed(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "policy.yaml")
+	if err := os.WriteFile(file, []byte(policyYAML), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := Init(file); err != nil {
+		t.Fatalf("Init() err: %v", err)
+	}
+
+	cases := []struct {
+		roles []string
+		perm  string
+		want  bool
+	}{
+		{[]string{"user"}, "user:read", true},
+		{[]string{"user"}, "user:delete", false},
+		{[]string{"editor"}, "user:read", true},
+		{[]string{"editor"}, "article:delete", true},
+		{[]string{"user", "editor"}, "article:read", true},
+		{[]string{"admin"}, "anything:at:all", true},
+		{[]string{"guest"}, "user:read", false},
+		{nil, "user:read", false},
+	}
+	for _, c := range cases {
+		if got := Allowed(c.roles, c.perm); got != c.want {
+			t.Errorf("Allowed(%v, %s) = %v, want %v", c.roles, c.perm, got, c.want)
+		}
+	}
+}
+
+func TestLoadRejectsCircularInherits(t *testing.T) {
+	p := Policy{Roles: map[string]Role{
+		"a": {Inherits: []string{"b"}},
+		"b": {Inherits: []string{"a"}},
+	}}
+	if err := Load(p); err == nil {
+		t.Error("Load() should reject circular inherits")
+	}
+}
+
+func TestGranted(t *testing.T) {
+	if !Granted([]string{"report:*"}, "report:export") {
+		t.Error("report:* should grant report:export")
+	}
+	if Granted([]string{"report:read"}, "report:export") {
+		t.Error("report:read should not grant report:export")
+	}
+}
@@ -0,0 +1,43 @@
+/**
+ * @Author: LiuShuXin
+ * @Description: 请求 ID 在 context 中的存取 用于串联同一请求的日志
+ * @File:  requestid
+ * Software: Goland
+ * @Date: 2026/10/19 18:00
+ */
+
+package requestid
+
+import "context"
+
+// Header 请求及响应中携带请求 ID 的头
+const Header = "X-Request-ID"
+
+// maxLen 客户端传入的请求 ID 最大长度 超出或包含非法字符时重新生成
+const maxLen = 128
+
+type ctxKey struct{}
+
+// NewContext 返回携带请求 ID 的 context
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, id)
+}
+
+// FromContext 获取请求 ID 不存在时返回空字符串
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(ctxKey{}).(string)
+	return id
+}
+
+// Valid 客户端传入的请求 ID 是否可直接使用 只允许可见的 ASCII 字符 避免日志注入
+func Valid(id string) bool {
+	if id == "" || len(id) > maxLen {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,63 @@
+/**
+ * @Author: LiuShuXin
+ * @Description: 服务优雅停机 按注册顺序依次执行关闭钩子
+ * @File:  shutdown
+ * Software: Goland
+ * @Date: 2026/10/18 10:12
+ */
+
+package shutdown
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+// Hook 关闭钩子
+type Hook struct {
+	Name string
+	Fn   func(ctx context.Context) error
+}
+
+var (
+	mu    sync.Mutex
+	hooks []Hook
+)
+
+// Register 注册关闭钩子 执行顺序与注册顺序一致
+// 一般先注册 HTTP 服务，再注册数据库连接池等依赖，最后注册日志
+func Register(name string, fn func(ctx context.Context) error) {
+	mu.Lock()
+	defer mu.Unlock()
+	hooks = append(hooks, Hook{Name: name, Fn: fn})
+}
+
+// Notify 监听 SIGINT / SIGTERM 信号
+func Notify() <-chan os.Signal {
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	return quit
+}
+
+// Run 依次执行所有关闭钩子
+// 单个钩子失败不会中断后续钩子，所有错误合并后返回
+func Run(ctx context.Context) error {
+	mu.Lock()
+	hs := make([]Hook, len(hooks))
+	copy(hs, hooks)
+	hooks = nil
+	mu.Unlock()
+
+	var errs []error
+	for _, h := range hs {
+		if err := h.Fn(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", h.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
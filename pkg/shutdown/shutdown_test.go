@@ -0,0 +1,78 @@
+/**
+ * @Author: LiuShuXin
+ * @Description:
+ * @File:  shutdown_test
+ * Software: Goland
+ * @Date: 2026/10/20 15:30
+ */
+
+package shutdown
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRunOrderAndErrors(t *testing.T) {
+	var order []string
+	errDB := errors.New("db close failed")
+	errLog := errors.New("sync failed")
+
+	Register("http", func(context.Context) error {
+		order = append(order, "http")
+		return nil
+	})
+	Register("mysql", func(context.Context) error {
+		order = append(order, "mysql")
+		return errDB
+	})
+	Register("logger", func(context.Context) error {
+		order = append(order, "logger")
+		return errLog
+	})
+
+	err := Run(context.Background())
+	if got := strings.Join(order, ","); got != "http,mysql,logger" {
+		t.Errorf("order = %s, want http,mysql,logger", got)
+	}
+	// 单个钩子失败不影响后续钩子 错误合并返回并带上钩子名
+	if !errors.Is(err, errDB) || !errors.Is(err, errLog) {
+		t.Errorf("err = %v, want both hook errors", err)
+	}
+	if err == nil || !strings.Contains(err.Error(), "mysql: ") || !strings.Contains(err.Error(), "logger: ") {
+		t.Errorf("err = %v, want hook names", err)
+	}
+
+	// 已执行的钩子被清空 再次调用不会重复执行
+	order = nil
+	if err = Run(context.Background()); err != nil || len(order) != 0 {
+		t.Errorf("second Run() = %v, order = %v", err, order)
+	}
+}
+
+func TestRunDeadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	Register("http", func(ctx context.Context) error {
+		<-ctx.Done() // 模拟超时未排空的 HTTP 服务
+		return ctx.Err()
+	})
+	var flushed bool
+	Register("logger", func(context.Context) error {
+		flushed = true
+		return nil
+	})
+
+	err := Run(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want %v", err, context.DeadlineExceeded)
+	}
+	// 超时后仍需执行后续钩子 以便刷新日志等
+	if !flushed {
+		t.Error("hooks after a timed out hook should still run")
+	}
+}
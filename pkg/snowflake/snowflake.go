@@ -9,12 +9,13 @@
 package snowflake
 
 import (
+	"sync/atomic"
 	"time"
 
 	sf "github.com/bwmarrin/snowflake"
 )
 
-var node *sf.Node
+var node atomic.Pointer[sf.Node]
 
 func Init(startTime string, machineID int64) (err error) {
 	var st time.Time
@@ -23,9 +24,18 @@ func Init(startTime string, machineID int64) (err error) {
 		return
 	}
 	sf.Epoch = st.UnixNano() / 1000000
-	node, err = sf.NewNode(machineID)
+	n, err := sf.NewNode(machineID)
+	if err != nil {
+		return
+	}
+	node.Store(n)
 	return
 }
+
 func GenID() int64 {
-	return node.Generate().Int64()
+	n := node.Load()
+	if n == nil {
+		panic("snowflake: node is not initialized")
+	}
+	return n.Generate().Int64()
 }
@@ -0,0 +1,128 @@
+/**
+ * @Author: LiuShuXin
+ * @Description: 基于 OpenTelemetry 的链路追踪 使用 W3C traceparent 在服务间传递
+ * @File:  tracing
+ * Software: Goland
+ * @Date: 2026/10/19 20:00
+ */
+
+package tracing
+
+import (
+	"app-server/settings"
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+
+	instrumentationName = "app-server"
+)
+
+var provider *sdktrace.TracerProvider
+
+func init() {
+	// 未开启追踪时同样传递上游的 traceparent 以免链路在本服务中断
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+}
+
+// Init 按配置创建 TracerProvider 未开启时使用默认的空实现 不产生任何开销
+func Init(cfg *settings.Tracing, serviceName, version string) error {
+	if cfg == nil || !cfg.Enable {
+		return nil
+	}
+	exporter, err := newExporter(cfg)
+	if err != nil {
+		return err
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName(serviceName),
+		semconv.ServiceVersion(version),
+	))
+	if err != nil {
+		return err
+	}
+
+	ratio := cfg.SampleRatio
+	if ratio <= 0 || ratio > 1 {
+		ratio = 1
+	}
+	provider = sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+	)
+	otel.SetTracerProvider(provider)
+	return nil
+}
+
+func newExporter(cfg *settings.Tracing) (sdktrace.SpanExporter, error) {
+	switch cfg.Exporter {
+	case ExporterStdout:
+		return stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case ExporterOTLP, "":
+		opts := []otlptracehttp.Option{}
+		if cfg.Endpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpoint(cfg.Endpoint))
+		}
+		if cfg.Insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		// 仅创建客户端 不在启动时连接 collector
+		return otlptracehttp.New(context.Background(), opts...)
+	}
+	return nil, fmt.Errorf("tracing: unsupported exporter %s", cfg.Exporter)
+}
+
+// Close 导出缓冲中的 span 并关闭 停机流程中在 HTTP 服务排空之后调用
+func Close(ctx context.Context) error {
+	if provider == nil {
+		return nil
+	}
+	return provider.Shutdown(ctx)
+}
+
+// Tracer 本服务使用的 tracer
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Start 创建子 span 用于 DAO 等内部调用 如：
+//
+//	ctx, span := tracing.Start(ctx, "mysql.GetUserByUsername", semconv.DBSystemMySQL)
+//	defer func() { tracing.End(span, err) }()
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+}
+
+// End 结束 span err 不为空时记录错误
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// IDs 当前 context 中的 trace id 与 span id 不存在时返回空字符串
+func IDs(ctx context.Context) (traceID, spanID string) {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return "", ""
+	}
+	return sc.TraceID().String(), sc.SpanID().String()
+}
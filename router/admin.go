@@ -0,0 +1,84 @@
+/**
+ * @Author: LiuShuXin
+ * @Description: pprof、swagger、指标等管理路由 按运行模式开启 可挂载到独立端口 并需通过访问控制
+ * @File:  admin
+ * Software: Goland
+ * @Date: 2026/10/20 11:30
+ */
+
+package router
+
+import (
+	"app-server/controller"
+	"app-server/middlewares"
+	"app-server/pkg/metrics"
+	"app-server/settings"
+	"slices"
+
+	"github.com/gin-contrib/pprof"
+	"github.com/gin-gonic/gin"
+	ginSwagger "github.com/swaggo/gin-swagger"
+	"github.com/swaggo/gin-swagger/swaggerFiles"
+)
+
+// AdminOptions 管理路由配置
+type AdminOptions struct {
+	Pprof   bool
+	Swagger bool
+	Metrics bool   // Prometheus 指标 与 pprof 一样暴露运行时内部信息 需同样受保护
+	Addr    string // 独立监听地址 为空时挂载在业务端口上
+	Guard   gin.HandlerFunc
+}
+
+// Enabled 是否有需要挂载的管理路由
+func (o AdminOptions) Enabled() bool {
+	return o.Pprof || o.Swagger || o.Metrics
+}
+
+// NewAdminOptions 根据配置及当前运行模式生成管理路由配置 未配置时仅 debug 模式开启且只允许本机访问
+// 指标路由是否开启由 metrics 配置决定 需在 metrics.Init 之后调用
+func NewAdminOptions(cfg *settings.Admin, mode string) (AdminOptions, error) {
+	if cfg == nil {
+		cfg = &settings.Admin{PprofModes: []string{gin.DebugMode}, SwaggerModes: []string{gin.DebugMode}}
+	}
+	guard, err := middlewares.AdminGuard(cfg.Username, cfg.Password, cfg.AllowIPs)
+	if err != nil {
+		return AdminOptions{}, err
+	}
+	return AdminOptions{
+		Pprof:   slices.Contains(cfg.PprofModes, mode),
+		Swagger: slices.Contains(cfg.SwaggerModes, mode),
+		Metrics: metrics.Enabled(),
+		Addr:    cfg.Addr,
+		Guard:   guard,
+	}, nil
+}
+
+// SetupAdminRouter 独立端口的管理路由
+func SetupAdminRouter(o AdminOptions) *gin.Engine {
+	r := gin.New()
+	r.Use(middlewares.RequestID(), middlewares.Logger(), middlewares.Recovery(true))
+	mountAdmin(r, o)
+	return r
+}
+
+// mountAdmin 挂载 pprof、swagger 及指标路由
+func mountAdmin(r gin.IRouter, o AdminOptions) {
+	if !o.Enabled() {
+		return
+	}
+	var g gin.IRouter = r
+	if o.Guard != nil {
+		g = r.Group("", o.Guard)
+	}
+	if o.Pprof {
+		pprof.RouteRegister(g, pprof.DefaultPrefix)
+		g.GET("/debug/health", controller.ReadyzDetail) // 含错误详情的就绪检查结果
+	}
+	if o.Swagger {
+		g.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	}
+	if o.Metrics {
+		g.GET(metrics.Path(), gin.WrapH(metrics.Handler()))
+	}
+}
@@ -0,0 +1,53 @@
+/**
+ * @Author: LiuShuXin
+ * @Description:
+ * @File:  admin_test
+ * Software: Goland
+ * @Date: 2026/10/20 16:00
+ */
+
+package router
+
+import (
+	"app-server/pkg/metrics"
+	"app-server/settings"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestMountAdmin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	o, err := NewAdminOptions(&settings.Admin{PprofModes: []string{"debug"}}, "release")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = NewAdminOptions(&settings.Admin{Username: "admin"}, "debug"); err == nil {
+		t.Error("username without password should fail")
+	}
+	o.Metrics = true
+	r := gin.New()
+	mountAdmin(r, o)
+
+	cases := []struct {
+		path   string
+		remote string
+		status int
+	}{
+		{metrics.Path(), "127.0.0.1:1234", http.StatusOK},
+		{metrics.Path(), "10.0.0.8:1234", http.StatusForbidden},
+		{"/debug/pprof/", "127.0.0.1:1234", http.StatusNotFound}, // release 模式未开启 pprof
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+		req.RemoteAddr = tc.remote
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if w.Code != tc.status {
+			t.Errorf("%s from %s: status = %d, want %d", tc.path, tc.remote, w.Code, tc.status)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+/**
+ * @Author: LiuShuXin
+ * @Description: 认证相关路由
+ * @File:  auth
+ * Software: Goland
+ * @Date: 2026/10/19 16:20
+ */
+
+package router
+
+import (
+	"app-server/controller"
+	"app-server/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+func init() {
+	Register(V1, ModuleFunc(authRoutes))
+}
+
+func authRoutes(g *gin.RouterGroup) {
+	auth := g.Group("/auth")
+	{
+		auth.POST("/signup", controller.SignUp)
+		auth.POST("/login", controller.Login)
+		auth.POST("/refresh", middlewares.RefreshTokenCookie(), controller.Refresh)
+		auth.POST("/logout", middlewares.JWTAuthMiddleware(), middlewares.RefreshTokenCookie(), controller.Logout)
+		auth.GET("/me", middlewares.JWTOrAPIKeyAuthMiddleware(), controller.Me)
+	}
+}
@@ -0,0 +1,146 @@
+/**
+ * @Author: LiuShuXin
+ * @Description: 业务模块路由注册 各模块实现 Module 后在 SetupRouter 之前调用 Register 挂载到 /api/{version} 下
+ * @File:  module
+ * Software: Goland
+ * @Date: 2026/10/19 16:00
+ */
+
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	APIPrefix = "/api"
+	V1        = "v1"
+	V2        = "v2"
+)
+
+// Module 业务模块 在版本路由组下注册自己的路由
+type Module interface {
+	RegisterRoutes(g *gin.RouterGroup)
+}
+
+// ModuleFunc 以函数实现 Module
+type ModuleFunc func(g *gin.RouterGroup)
+
+func (f ModuleFunc) RegisterRoutes(g *gin.RouterGroup) {
+	f(g)
+}
+
+// Version 接口版本
+type Version struct {
+	Name        string            // 版本号 如 v1 路由前缀为 /api/v1
+	Middlewares []gin.HandlerFunc // 该版本下所有路由共用的中间件
+	Deprecated  bool
+	DeprecateAt time.Time // 废弃时间 为零值时 Deprecation 响应头为 true
+	Sunset      time.Time // 计划下线时间 为零值时不返回 Sunset 响应头
+	Link        string    // 迁移说明文档地址
+}
+
+type entry struct {
+	module      Module
+	middlewares []gin.HandlerFunc
+}
+
+var (
+	mu       sync.Mutex
+	versions = map[string]*Version{V1: {Name: V1}, V2: {Name: V2}}
+	modules  = map[string][]entry{}
+)
+
+// RegisterVersion 新增或替换接口版本
+func RegisterVersion(v Version) {
+	mu.Lock()
+	defer mu.Unlock()
+	versions[v.Name] = &v
+}
+
+// Use 为版本追加中间件 如 router.Use(router.V2, middlewares.JWTAuthMiddleware())
+func Use(version string, middlewares ...gin.HandlerFunc) {
+	mu.Lock()
+	defer mu.Unlock()
+	v := mustVersion(version)
+	v.Middlewares = append(v.Middlewares, middlewares...)
+}
+
+// Deprecate 废弃版本 该版本的响应将携带 Deprecation、Sunset 及 Link 响应头
+func Deprecate(version string, sunset time.Time, link string) {
+	mu.Lock()
+	defer mu.Unlock()
+	v := mustVersion(version)
+	v.Deprecated, v.DeprecateAt, v.Sunset, v.Link = true, time.Now(), sunset, link
+}
+
+// Register 将模块注册到版本下 middlewares 仅作用于该模块的路由
+func Register(version string, m Module, middlewares ...gin.HandlerFunc) {
+	mu.Lock()
+	defer mu.Unlock()
+	mustVersion(version)
+	modules[version] = append(modules[version], entry{module: m, middlewares: middlewares})
+}
+
+func mustVersion(name string) *Version {
+	v, ok := versions[name]
+	if !ok {
+		panic(fmt.Sprintf("router: unknown api version %s", name))
+	}
+	return v
+}
+
+// mountModules 按版本号顺序挂载已注册的模块
+func mountModules(r gin.IRouter) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	names := make([]string, 0, len(versions))
+	for name := range versions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	api := r.Group(APIPrefix)
+	for _, name := range names {
+		v := versions[name]
+		handlers := v.Middlewares
+		if v.Deprecated {
+			handlers = append([]gin.HandlerFunc{deprecation(*v)}, handlers...)
+		}
+		g := api.Group("/"+name, handlers...)
+		for _, e := range modules[name] {
+			e.module.RegisterRoutes(g.Group("", e.middlewares...))
+		}
+	}
+}
+
+// deprecation 按 RFC 9745、RFC 8594 设置版本废弃相关响应头
+func deprecation(v Version) gin.HandlerFunc {
+	dep := "true"
+	if !v.DeprecateAt.IsZero() {
+		dep = "@" + strconv.FormatInt(v.DeprecateAt.Unix(), 10)
+	}
+	var sunset string
+	if !v.Sunset.IsZero() {
+		sunset = v.Sunset.UTC().Format(http.TimeFormat)
+	}
+	return func(c *gin.Context) {
+		h := c.Writer.Header()
+		h.Set("Deprecation", dep)
+		if sunset != "" {
+			h.Set("Sunset", sunset)
+		}
+		if v.Link != "" {
+			h.Add("Link", "<"+v.Link+`>; rel="deprecation"`)
+		}
+		c.Next()
+	}
+}
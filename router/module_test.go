@@ -0,0 +1,85 @@
+/**
+ * @Author: LiuShuXin
+ * @Description:
+ * @File:  module_test
+ * Software: Goland
+ * @Date: 2026/10/19 16:40
+ */
+
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestMountModules(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	RegisterVersion(Version{Name: "v9"})
+	Use("v9", func(c *gin.Context) {
+		c.Header("X-Version", "v9")
+	})
+	Register("v9", ModuleFunc(func(g *gin.RouterGroup) {
+		g.GET("/items", func(c *gin.Context) {
+			c.String(http.StatusOK, c.GetString("module"))
+		})
+	}), func(c *gin.Context) {
+		c.Set("module", "items")
+	})
+	sunset := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+	Deprecate("v9", sunset, "https://example.com/migrate")
+	defer func() {
+		mu.Lock()
+		delete(versions, "v9")
+		delete(modules, "v9")
+		mu.Unlock()
+	}()
+
+	r := gin.New()
+	mountModules(r)
+
+	cases := []struct {
+		path   string
+		status int
+	}{
+		{"/api/v9/items", http.StatusOK},
+		{"/api/v1/auth/me", http.StatusUnauthorized},
+	}
+	for _, c := range cases {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, c.path, nil))
+		if w.Code != c.status {
+			t.Errorf("GET %s = %d, want %d", c.path, w.Code, c.status)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v9/items", nil))
+	h := w.Header()
+	if w.Body.String() != "items" || h.Get("X-Version") != "v9" {
+		t.Errorf("middlewares not applied: body=%q headers=%v", w.Body.String(), h)
+	}
+	if h.Get("Deprecation") == "" || h.Get("Deprecation")[0] != '@' {
+		t.Errorf("Deprecation = %q", h.Get("Deprecation"))
+	}
+	if h.Get("Sunset") != "Fri, 01 Jan 2027 00:00:00 GMT" {
+		t.Errorf("Sunset = %q", h.Get("Sunset"))
+	}
+	if h.Get("Link") != `<https://example.com/migrate>; rel="deprecation"` {
+		t.Errorf("Link = %q", h.Get("Link"))
+	}
+}
+
+func TestRegisterUnknownVersion(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Register with unknown version should panic")
+		}
+	}()
+	Register("v0", ModuleFunc(func(*gin.RouterGroup) {}))
+}
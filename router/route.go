@@ -9,21 +9,24 @@
 package router
 
 import (
+	"app-server/controller"
 	"app-server/middlewares"
 	"github.com/gin-gonic/gin"
-	ginSwagger "github.com/swaggo/gin-swagger"
-	"github.com/swaggo/gin-swagger/swaggerFiles"
 	"net/http"
-
-	"github.com/gin-contrib/pprof"
 )
 
-func SetupRouter(mode string) *gin.Engine {
+// SetupRouter 业务路由 admin 未配置独立监听地址时 pprof、swagger、指标挂载在业务端口上
+func SetupRouter(mode string, admin AdminOptions) *gin.Engine {
 	gin.SetMode(mode)
 
 	r := gin.New()
+	// 请求 ID 及链路追踪需在日志中间件之前 以便日志中带上 request_id、trace_id
+	// 指标统计的耗时包含其后的日志、recovery 等中间件
+	r.Use(middlewares.RequestID(), middlewares.Tracing(), middlewares.Metrics())
 	// 使用自定义logger、recovery中间件取代gin默认的
 	r.Use(middlewares.Logger(), middlewares.Recovery(true))
+	// 统一渲染处理函数中 c.Error 附加的错误
+	r.Use(middlewares.ErrorHandler())
 
 	r.LoadHTMLGlob("templates/*") // 加载模板
 	r.Static("/assets", "static") // 设置静态文件路径
@@ -36,17 +39,30 @@ func SetupRouter(mode string) *gin.Engine {
 		c.String(http.StatusOK, "pong")
 	})
 
-	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	// 存活及就绪探针
+	r.GET("/healthz", controller.Healthz)
+	r.GET("/readyz", controller.Readyz)
 
-	pprof.Register(r) // 注册pprof相关路由
+	if admin.Addr == "" {
+		mountAdmin(r, admin)
+	}
 
-	// 自定义路由
+	// 认证相关 /auth 为兼容旧客户端保留 新客户端请使用 /api/v1/auth
+	authRoutes(&r.RouterGroup)
 
-	r.NoRoute(func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{
-			"msg": "404",
-		})
-	})
+	// 自定义路由 各业务模块实现 Module 并在调用 SetupRouter 前注册 如：
+	// router.Register(router.V1, user.Module{}, middlewares.JWTAuthMiddleware())
+	// 模块内的路由组可声明允许访问的角色或所需权限：
+	// admin := g.Group("/admin", middlewares.RequireRoles(models.RoleAdmin))
+	// admin.DELETE("/user/:id", middlewares.RequirePermission("user:delete"), controller.DeleteUser)
+	// 供批处理任务调用的路由组可同时接受 JWT 与 API Key：
+	// batch := g.Group("/batch", middlewares.JWTOrAPIKeyAuthMiddleware(), middlewares.RequirePermission("report:export"))
+	// 废弃旧版本：router.Deprecate(router.V1, sunset, "https://example.com/docs/migrate-v2")
+	mountModules(r)
+
+	r.HandleMethodNotAllowed = true
+	r.NoRoute(controller.NoRoute)
+	r.NoMethod(controller.NoMethod)
 
 	return r
 
@@ -28,7 +28,16 @@ type SvrConfig struct {
 	Addr      string `mapstructure:"addr"`
 	Port      int    `mapstructure:"port"`
 
+	ShutdownTimeout  int    `mapstructure:"shutdown_timeout"`       // 优雅停机等待在途请求的最长时间 s
+	ShutdownDrain    int    `mapstructure:"shutdown_drain_seconds"` // 收到停机信号后就绪探针失败 保持服务该时长后再关闭监听 s
+	StrictHTTPStatus bool   `mapstructure:"strict_http_status"`     // 错误响应使用错误码对应的 HTTP 状态码 关闭时统一返回 200
+	I18nDir          string `mapstructure:"i18n_dir"`               // 状态码提示语言包目录 为空时使用 ./conf/i18n
+
 	*Auth        `mapstructure:"auth"`
+	*Pagination  `mapstructure:"pagination"`
+	*Tracing     `mapstructure:"tracing"`
+	*Metrics     `mapstructure:"metrics"`
+	*Admin       `mapstructure:"admin"`
 	*LogConfig   `mapstructure:"log"`
 	*MySQLConfig `mapstructure:"mysql"`
 	*RedisConfig `mapstructure:"redis"`
@@ -36,9 +45,76 @@ type SvrConfig struct {
 }
 
 type Auth struct {
-	JwtExpire int64 `mapstructure:"jwt_expire"` // token过期时间
+	JwtExpire        int64        `mapstructure:"jwt_expire"`         // 已废弃 未配置 jwt_access_expire 时作为 access token 过期时间 h
+	JwtAccessExpire  int64        `mapstructure:"jwt_access_expire"`  // access token 过期时间 min
+	JwtRefreshExpire int64        `mapstructure:"jwt_refresh_expire"` // refresh token 过期时间 h
+	JwtSecret        string       `mapstructure:"jwt_secret"`         // HS256 签名密钥
+	JwtIssuer        string       `mapstructure:"jwt_issuer"`         // 签发人
+	JwtAudience      string       `mapstructure:"jwt_audience"`       // 接收方
+	JwtAlgorithm     string       `mapstructure:"jwt_algorithm"`      // 签名算法 HS256 | RS256 | ES256
+	JwtPrivateKey    string       `mapstructure:"jwt_private_key"`    // RS256 / ES256 私钥 PEM 文件路径
+	JwtPublicKey     string       `mapstructure:"jwt_public_key"`     // RS256 / ES256 公钥 PEM 文件路径
+	PolicyFile       string       `mapstructure:"policy_file"`        // 角色权限策略文件
+	TokenLookup      string       `mapstructure:"token_lookup"`       // 获取 token 的位置及优先级
+	TokenCookie      *TokenCookie `mapstructure:"token_cookie"`       // 登录后将 access token 写入 HttpOnly cookie
+	APIKeyStore      string       `mapstructure:"api_key_store"`      // API Key 存储 memory | redis
+	APIKeys          []APIKey     `mapstructure:"api_keys"`           // memory 存储时加载的 API Key
+}
+
+// Pagination 列表接口分页参数
+type Pagination struct {
+	DefaultPageSize int `mapstructure:"default_page_size"` // 未传 pagesize 时的每页条数
+	MaxPageSize     int `mapstructure:"max_page_size"`     // 每页条数上限 超出时按上限返回
+}
+
+// Tracing 链路追踪
+type Tracing struct {
+	Enable      bool    `mapstructure:"enable"`
+	Exporter    string  `mapstructure:"exporter"`     // stdout | otlp
+	Endpoint    string  `mapstructure:"endpoint"`     // otlp collector 地址 如 localhost:4318
+	Insecure    bool    `mapstructure:"insecure"`     // otlp 使用 http 而非 https
+	SampleRatio float64 `mapstructure:"sample_ratio"` // 采样率 0~1 未配置时全部采样
+}
+
+// Metrics Prometheus 指标
+type Metrics struct {
+	Enable    bool      `mapstructure:"enable"`
+	Path      string    `mapstructure:"path"`      // 指标路由 默认 /metrics
+	Namespace string    `mapstructure:"namespace"` // 指标名前缀 默认为服务名
+	Buckets   []float64 `mapstructure:"buckets"`   // 请求耗时直方图的分桶 s 未配置时使用默认分桶
+}
+
+// Admin pprof、swagger 等管理路由
+type Admin struct {
+	PprofModes   []string `mapstructure:"pprof_modes"`   // 开启 pprof 的运行模式 如 [debug, test]
+	SwaggerModes []string `mapstructure:"swagger_modes"` // 开启 swagger 的运行模式
+	Addr         string   `mapstructure:"addr"`          // 单独的管理端口 如 127.0.0.1:8001 为空时挂载在业务端口
+	Username     string   `mapstructure:"username"`      // basic auth 用户名 为空时不校验
+	Password     string   `mapstructure:"password"`
+	AllowIPs     []string `mapstructure:"allow_ips"` // 允许访问的 IP 或网段 与 basic auth 均未配置时仅允许本机访问
+}
+
+type TokenCookie struct {
+	Enable      bool   `mapstructure:"enable"`
+	Name        string `mapstructure:"name"`
+	RefreshName string `mapstructure:"refresh_name"` // refresh token 的 cookie 名 为空时为 refresh_token 仅随 /auth 下的请求发送
+	Domain      string `mapstructure:"domain"`
+	Path        string `mapstructure:"path"`
+	Secure      bool   `mapstructure:"secure"`    // 仅 debug 模式下可关闭 其他模式始终为 Secure
+	SameSite    string `mapstructure:"same_site"` // lax | strict | none
+	// 允许使用 cookie 认证发起写请求的其他来源 同域名请求始终允许
+	TrustedOrigins []string `mapstructure:"trusted_origins"`
+}
+
+// APIKey 服务间调用使用的 API Key 只配置明文的 sha256 哈希
+type APIKey struct {
+	Name      string   `mapstructure:"name"`
+	Hash      string   `mapstructure:"hash"`
+	Scopes    []string `mapstructure:"scopes"`
+	ExpiresAt string   `mapstructure:"expires_at"` // 过期时间 RFC3339 或日期 2006-01-02（当天结束前有效）为空表示永不过期
 }
 type MongoConfig struct {
+	Enable        bool       `mapstructure:"enable"`
 	Uri           string     `mapstructure:"uri"`
 	ConnectTimout int        `mapstructure:"connect-timeout"` // 连接超时时间 s
 	Database      string     `mapstructure:"database"`
@@ -54,6 +130,7 @@ type Credential struct {
 }
 
 type MySQLConfig struct {
+	Enable       bool   `mapstructure:"enable"`
 	Host         string `mapstructure:"host"`
 	Port         int    `mapstructure:"port"`
 	User         string `mapstructure:"user"`
@@ -67,6 +144,7 @@ type MySQLConfig struct {
 }
 
 type RedisConfig struct {
+	Enable       bool   `mapstructure:"enable"`
 	Host         string `mapstructure:"host"`
 	Password     string `mapstructure:"password"`
 	Port         int    `mapstructure:"port"`